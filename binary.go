@@ -0,0 +1,78 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"slices"
+	"time"
+)
+
+// AppendBinary appends the 16 raw bytes of each UUID to dst and returns the
+// extended slice
+func AppendBinary(dst []byte, ids ...UUID) []byte {
+	dst = slices.Grow(dst, 16*len(ids))
+	for i := range ids {
+		dst = append(dst, ids[i][:]...)
+	}
+	return dst
+}
+
+// DecodeBinarySlice splits a packed frame produced by AppendBinary into UUIDs.
+// It returns ErrInvalidByteSlice if len(b) is not a multiple of 16.
+func DecodeBinarySlice(b []byte) ([]UUID, error) {
+	if len(b)%16 != 0 {
+		return nil, ErrInvalidByteSlice
+	}
+	ids := make([]UUID, len(b)/16)
+	for i := range ids {
+		copy(ids[i][:], b[16*i:])
+	}
+	return ids, nil
+}
+
+// recordSize is the length of a record from MarshalRecord
+const recordSize = 24
+
+// MarshalRecord packs the façade with its creation time as a 24-byte record:
+// the 16 façade bytes followed by the decoded Unix millisecond timestamp as a
+// big-endian uint64. The time is stored in the clear, so the record must
+// stay as private as the UUIDv7 itself.
+func (u *UUID) MarshalRecord(key Key) ([]byte, error) {
+	b := make([]byte, recordSize)
+	copy(b, u[:])
+	wr64be(b[16:], decodeTimestamp(u, key))
+	return b, nil
+}
+
+// UnmarshalRecord unpacks a record from MarshalRecord, returning the façade
+// and its creation time in UTC. It returns ErrInvalidByteSlice if b is not 24
+// bytes, and ErrRecordMismatch if the stored time differs from the one
+// decoded from the façade, which indicates tampering or the wrong key.
+func UnmarshalRecord(b []byte, key Key) (UUID, time.Time, error) {
+	if len(b) != recordSize {
+		return UUID{}, time.Time{}, ErrInvalidByteSlice
+	}
+	facade := UUID(b[:16])
+	ts := rd64be(b[16:])
+	if ts != decodeTimestamp(&facade, key) {
+		return UUID{}, time.Time{}, ErrRecordMismatch
+	}
+	return facade, msToTime(ts), nil
+}
+
+// ExtendedKey returns an 18-byte composite key of the 16 UUID bytes followed
+// by seq in big-endian order, so keys compare bytewise by UUID and then seq
+func (u *UUID) ExtendedKey(seq uint16) [18]byte {
+	var k [18]byte
+	copy(k[:], u[:])
+	k[16] = byte(seq >> 8)
+	k[17] = byte(seq)
+	return k
+}
+
+// ParseExtendedKey splits a key from ExtendedKey into its UUID and sequence
+func ParseExtendedKey(k [18]byte) (UUID, uint16) {
+	return UUID(k[:16]), uint16(k[16])<<8 | uint16(k[17])
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestAppendBinary(t *testing.T) {
+	a := NewV7Exact(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	b := Max
+
+	prefix := []byte{0xAA, 0xBB}
+	frame := AppendBinary(prefix, a, b)
+	if len(frame) != 2+32 {
+		t.Fatalf("AppendBinary() length: got %d, want 34", len(frame))
+	}
+	if !bytes.Equal(frame[:2], prefix) || !bytes.Equal(frame[2:18], a[:]) || !bytes.Equal(frame[18:], b[:]) {
+		t.Errorf("AppendBinary() mismatch: %x", frame)
+	}
+
+	if got := AppendBinary(nil); len(got) != 0 {
+		t.Errorf("AppendBinary(nil): got %x, want empty", got)
+	}
+
+	ids, err := DecodeBinarySlice(frame[2:])
+	if err != nil {
+		t.Fatalf("DecodeBinarySlice() error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != a || ids[1] != b {
+		t.Errorf("DecodeBinarySlice() mismatch: got %v", ids)
+	}
+}
+
+func TestDecodeBinarySlice(t *testing.T) {
+	ids, err := DecodeBinarySlice(nil)
+	if err != nil || len(ids) != 0 {
+		t.Errorf("DecodeBinarySlice(nil): got %v, %v", ids, err)
+	}
+
+	for _, n := range []int{1, 15, 17, 33} {
+		if _, err := DecodeBinarySlice(make([]byte, n)); err != ErrInvalidByteSlice {
+			t.Errorf("DecodeBinarySlice(%d bytes): got %v, want %v", n, err, ErrInvalidByteSlice)
+		}
+	}
+
+	// The result must not alias the input
+	frame := AppendBinary(nil, Max)
+	ids, _ = DecodeBinarySlice(frame)
+	frame[0] = 0
+	if ids[0] != Max {
+		t.Error("DecodeBinarySlice() result aliases the input")
+	}
+}
+
+func TestMarshalRecord(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	facade := Encode(NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF), key)
+
+	rec, err := facade.MarshalRecord(key)
+	if err != nil {
+		t.Fatalf("MarshalRecord() error: %v", err)
+	}
+	if len(rec) != 24 || !bytes.Equal(rec[:16], facade[:]) {
+		t.Fatalf("MarshalRecord(): got %x", rec)
+	}
+	if got := rd64be(rec[16:]); got != uint64(created.UnixMilli()) {
+		t.Errorf("MarshalRecord() timestamp: got %d, want %d", got, created.UnixMilli())
+	}
+
+	u, ts, err := UnmarshalRecord(rec, key)
+	if err != nil || u != facade || !ts.Equal(created) || ts.Location() != time.UTC {
+		t.Errorf("UnmarshalRecord(): got %v, %v, %v, want %v, %v, nil", u, ts, err, facade, created)
+	}
+
+	tampered := bytes.Clone(rec)
+	tampered[23]++
+	swapped := bytes.Clone(rec)
+	swapped[15] ^= 0x01
+
+	tests := []struct {
+		name    string
+		b       []byte
+		key     Key
+		wantErr error
+	}{
+		{"tampered-time", tampered, key, ErrRecordMismatch},
+		{"tampered-facade", swapped, key, ErrRecordMismatch},
+		{"wrong-key", rec, Key{K0: key.K1, K1: key.K0}, ErrRecordMismatch},
+		{"short", rec[:23], key, ErrInvalidByteSlice},
+		{"long", append(bytes.Clone(rec), 0), key, ErrInvalidByteSlice},
+	}
+	for _, tt := range tests {
+		u, ts, err := UnmarshalRecord(tt.b, tt.key)
+		if err != tt.wantErr || !u.IsZero() || !ts.IsZero() {
+			t.Errorf("UnmarshalRecord(%s): got %v, %v, %v, want zero values and %v", tt.name, u, ts, err, tt.wantErr)
+		}
+	}
+}
+
+func TestExtendedKey(t *testing.T) {
+	a := NewV7Exact(1700000000000, 0x0ABC, 0x0123456789ABCDEF)
+	b, _ := a.Next()
+
+	k := a.ExtendedKey(0x0102)
+	if !bytes.Equal(k[:16], a[:]) || k[16] != 0x01 || k[17] != 0x02 {
+		t.Fatalf("ExtendedKey(0x0102): got %x", k)
+	}
+	if u, seq := ParseExtendedKey(k); u != a || seq != 0x0102 {
+		t.Errorf("ParseExtendedKey(%x): got %v, %#04x, want %v, 0x0102", k, u, seq, a)
+	}
+
+	// Keys must sort by UUID first and then by sequence
+	ordered := [][18]byte{
+		a.ExtendedKey(0),
+		a.ExtendedKey(0x00ff),
+		a.ExtendedKey(0x0100),
+		a.ExtendedKey(0xffff),
+		b.ExtendedKey(0),
+	}
+	for i := 1; i < len(ordered); i++ {
+		if bytes.Compare(ordered[i-1][:], ordered[i][:]) >= 0 {
+			t.Errorf("ExtendedKey order(%d): got %x >= %x", i, ordered[i-1], ordered[i])
+		}
+	}
+}
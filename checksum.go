@@ -0,0 +1,50 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+// luhn16 computes the Luhn mod 16 check nibble over the 32 nibbles of u
+func luhn16(u *UUID) byte {
+	sum := 0
+	factor := 2
+	for i := 31; i >= 0; i-- {
+		nibble := int(u[i/2] >> 4)
+		if i%2 == 1 {
+			nibble = int(u[i/2] & 0x0F)
+		}
+		addend := factor * nibble
+		sum += addend/16 + addend%16
+		factor = 3 - factor
+	}
+	return byte((16 - sum%16) % 16)
+}
+
+// StringWithChecksum returns the canonical form followed by one lowercase hex
+// Luhn mod 16 check character (37 characters). It catches single-character
+// typos and most adjacent transpositions in hand-entered IDs. This form is
+// opt-in and is not accepted by Parse.
+func (u *UUID) StringWithChecksum() string {
+	return u.String() + string(hexLower[luhn16(u)])
+}
+
+// ParseWithChecksum parses a string produced by StringWithChecksum, validates
+// the trailing check character and returns the UUID without it. It returns
+// ErrChecksumMismatch if the check character does not match.
+func ParseWithChecksum(s string) (UUID, error) {
+	if len(s) != 37 {
+		return UUID{}, ErrInvalidLength
+	}
+	u, err := Parse(s[:36])
+	if err != nil {
+		return UUID{}, err
+	}
+	check := hexval(s[36])
+	if check < 0 {
+		return UUID{}, ErrInvalidHex
+	}
+	if byte(check) != luhn16(&u) {
+		return UUID{}, ErrChecksumMismatch
+	}
+	return u, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"testing"
+)
+
+func TestStringWithChecksum(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	s := u.StringWithChecksum()
+	if len(s) != 37 {
+		t.Fatalf("StringWithChecksum() length: got %d, want 37", len(s))
+	}
+	if s[:36] != u.String() {
+		t.Errorf("StringWithChecksum() prefix: got %s, want %s", s[:36], u.String())
+	}
+
+	back, err := ParseWithChecksum(s)
+	if err != nil {
+		t.Fatalf("ParseWithChecksum(%q) failed: %v", s, err)
+	}
+	if back != u {
+		t.Errorf("ParseWithChecksum mismatch: got %v, want %v", back, u)
+	}
+
+	// Every single-character substitution must be caught
+	for i := range 36 {
+		if s[i] == '-' {
+			continue
+		}
+		for _, c := range []byte("0123456789abcdef") {
+			if c == s[i] {
+				continue
+			}
+			typo := s[:i] + string(c) + s[i+1:]
+			if _, err := ParseWithChecksum(typo); err != ErrChecksumMismatch {
+				t.Errorf("ParseWithChecksum(%q): got %v, want %v", typo, err, ErrChecksumMismatch)
+			}
+		}
+	}
+}
+
+func TestParseWithChecksumErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", ErrInvalidLength},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6fzz", ErrInvalidLength},
+		{"018f2d9f_9a2a-7def-8c3f-7b1a2c4d5e6f0", ErrInvalidFormat},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6fz", ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		if _, err := ParseWithChecksum(tt.in); err != tt.want {
+			t.Errorf("ParseWithChecksum(%q): got %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}
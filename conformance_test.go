@@ -0,0 +1,38 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSipHashConformanceError(t *testing.T) {
+	tests := []struct {
+		name   string
+		k0, k1 uint64
+	}{
+		{"reference", 0x0706050403020100, 0x0f0e0d0c0b0a0908},
+		{"other", 0x0123456789abcdef, 0xfedcba9876543210},
+		{"zero", 0, 0},
+	}
+	for _, tt := range tests {
+		if err := SipHashConformanceError(tt.k0, tt.k1); err != nil {
+			t.Errorf("SipHashConformanceError(%s): got %v, want nil", tt.name, err)
+		}
+	}
+
+	saved := sipHashVectors[17]
+	sipHashVectors[17] ^= 1
+	defer func() { sipHashVectors[17] = saved }()
+
+	err := SipHashConformanceError(0x0706050403020100, 0x0f0e0d0c0b0a0908)
+	if err == nil || !strings.Contains(err.Error(), "17-byte message") {
+		t.Errorf("SipHashConformanceError(corrupt 17): got %v, want 17-byte mismatch", err)
+	}
+	if err := SipHashConformanceError(1, 2); err != nil {
+		t.Errorf("SipHashConformanceError(other, corrupt 17): got %v, want nil", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+// EncodeFull and DecodeFull hide all 122 non-fixed bits of a UUIDv7, not only
+// the timestamp. Encode cannot do this, because it keys the mask on the random
+// bits it leaves in place. Instead the bits are split into two halves at fixed
+// positions, L = ts48||rand_a (60 bits) and R = rand_b (62 bits), and run
+// through a four-round unbalanced Feistel network. Each round XORs one half
+// with a SipHash-2-4 of the other half and the round number, so every round
+// is undone by repeating it, and four rounds give a strong pseudorandom
+// permutation.
+
+const (
+	feistelRounds = 4
+	feistelLMask  = 1<<60 - 1
+	feistelRMask  = 1<<62 - 1
+)
+
+// feistelRound returns the round function output for half x in round i
+func feistelRound(st *sipState, x uint64, i int) uint64 {
+	var msg [9]byte
+	for j := range 8 {
+		msg[j] = byte(x >> (8 * j))
+	}
+	msg[8] = byte(i)
+	return st.sum(msg[:])
+}
+
+// feistelHalves splits the UUID into its 60-bit and 62-bit Feistel halves
+func feistelHalves(u *UUID) (l, r uint64) {
+	return rd48be(u[0:6])<<12 | uint64(randA12(u)), randB62(u)
+}
+
+// EncodeFull encodes a UUIDv7 as a UUIDv4 façade with the timestamp and both
+// random fields permuted under key, so no bit of the v7 shows through. Only
+// DecodeFull reverses it; the output is not compatible with Decode.
+func EncodeFull(v7 UUID, key Key) UUID {
+	st := newSipState(key.K0, key.K1)
+	l, r := feistelHalves(&v7)
+	for i := range feistelRounds {
+		if i%2 == 0 {
+			r ^= feistelRound(&st, l, i) & feistelRMask
+		} else {
+			l ^= feistelRound(&st, r, i) & feistelLMask
+		}
+	}
+	out := NewV7Exact(l>>12, uint16(l), r)
+	out.setVersion(4)
+	return out
+}
+
+// DecodeFull decodes a façade made by EncodeFull with the same key
+func DecodeFull(v4facade UUID, key Key) UUID {
+	st := newSipState(key.K0, key.K1)
+	l, r := feistelHalves(&v4facade)
+	for i := feistelRounds - 1; i >= 0; i-- {
+		if i%2 == 0 {
+			r ^= feistelRound(&st, l, i) & feistelRMask
+		} else {
+			l ^= feistelRound(&st, r, i) & feistelLMask
+		}
+	}
+	return NewV7Exact(l>>12, uint16(l), r)
+}
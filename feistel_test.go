@@ -0,0 +1,54 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestEncodeDecodeFull(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	other := Key{K0: key.K1, K1: key.K0}
+
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+	for i := range 1000 {
+		v7 := NewV7Exact(rng.next(), uint16(rng.next()), rng.next())
+		facade := EncodeFull(v7, key)
+
+		if facade.Version() != 4 || (facade[8]&0xC0) != 0x80 {
+			t.Fatalf("EncodeFull(%d) version/variant: got %d/0x%X", i, facade.Version(), facade[8]&0xC0)
+		}
+		if back := DecodeFull(facade, key); back != v7 {
+			t.Fatalf("DecodeFull(%d): got %v, want %v", i, back, v7)
+		}
+		if randB62(&facade) == randB62(&v7) || rd48be(facade[0:6]) == rd48be(v7[0:6]) {
+			t.Errorf("EncodeFull(%d) left fields unchanged: %v -> %v", i, v7, facade)
+		}
+		if DecodeFull(facade, other) == v7 {
+			t.Errorf("DecodeFull(%d) with the wrong key should not recover the v7", i)
+		}
+	}
+
+	// Flipping any one input bit changes about half of the 122 output bits
+	v7 := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	base := EncodeFull(v7, key)
+	for _, bit := range []int{0, 47, 52, 63, 66, 127} {
+		flipped := v7
+		flipped[bit/8] ^= 0x80 >> (bit % 8)
+		f := EncodeFull(flipped, key)
+		n := 0
+		for j := range f {
+			n += bits.OnesCount8(f[j] ^ base[j])
+		}
+		if n < 30 || n > 92 {
+			t.Errorf("EncodeFull() flipping bit %d changed %d output bits", bit, n)
+		}
+	}
+
+	if EncodeFull(v7, key) == Encode(v7, key) {
+		t.Error("EncodeFull() should differ from Encode")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"fmt"
+	"io"
+)
+
+// fixtureEpochMs is the timestamp of index 0 in EncodeDeterministic (2024-01-01T00:00:00Z)
+const fixtureEpochMs = 1704067200000
+
+// splitmix64 advances the state and returns the next SplitMix64 output
+func splitmix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// xorshift64star is a simple non-cryptographic PRNG for fixtures and benchmarks
+type xorshift64star uint64
+
+// next returns the next xorshift64* output
+func (x *xorshift64star) next() uint64 {
+	v := uint64(*x)
+	v ^= v >> 12
+	v ^= v << 25
+	v ^= v >> 27
+	*x = xorshift64star(v)
+	return v * 2685821657736338717
+}
+
+// EncodeDeterministic is a fixtures helper for golden-file tests.
+// It derives a fully deterministic UUIDv7 from index and returns its façade
+// under key. The timestamp is 2024-01-01T00:00:00Z plus index milliseconds,
+// and the random fields are SplitMix64 outputs seeded with index.
+// The same index and key always produce the same façade.
+// Do not use it to mint real identifiers.
+func EncodeDeterministic(index uint64, key Key) UUID {
+	state := index
+	randA := uint16(splitmix64(&state) & 0x0FFF)
+	randB := splitmix64(&state) & ((1 << 62) - 1)
+	return Encode(NewV7Exact(fixtureEpochMs+index, randA, randB), key)
+}
+
+// NewV4FromSeed is a fixtures helper that returns a plain UUIDv4 (not a
+// façade) filled from an xorshift64* generator seeded with seed. The same
+// seed always yields the same UUID. It is not cryptographically random;
+// use it only for stable test data.
+func NewV4FromSeed(seed uint64) UUID {
+	// Mix the seed first; xorshift64* is stuck at zero for a zero state
+	state := seed
+	rng := xorshift64star(splitmix64(&state) | 1)
+
+	var u UUID
+	hi, lo := rng.next(), rng.next()
+	for i := range 8 {
+		u[i] = byte(hi >> (56 - 8*i))
+		u[8+i] = byte(lo >> (56 - 8*i))
+	}
+	u.setVersion(4)
+	u.setVariantRFC4122()
+	return u
+}
+
+// RoundTripSample encodes and decodes n random UUIDv7s under key and returns
+// an error describing the first one that does not round-trip, so downstream
+// test suites can check their key configuration against the library. The
+// error names the failing v7 and the key's Fingerprint, never the key
+// itself. It also returns any crypto/rand read error.
+func RoundTripSample(key Key, n int) error {
+	var u7 UUID
+	for range n {
+		if _, err := io.ReadFull(randReader, u7[:]); err != nil {
+			return err
+		}
+		u7.setVersion(7)
+		u7.setVariantRFC4122()
+
+		facade := Encode(u7, key)
+		if back := Decode(facade, key); back != u7 {
+			return roundTripError(u7, facade, back, key)
+		}
+	}
+	return nil
+}
+
+// roundTripError describes a failed RoundTripSample round trip
+func roundTripError(u7, facade, back UUID, key Key) error {
+	return fmt.Errorf("uuid47: round trip failed for %s under key %08x: façade %s decoded to %s",
+		u7.String(), key.Fingerprint(), facade.String(), back.String())
+}
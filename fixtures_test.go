@@ -0,0 +1,106 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeDeterministic(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	golden := []string{
+		"067203f2-dcb1-4daf-ae78-9e6aa1b965f4",
+		"cd27c0cb-f6f3-4cc1-beeb-8da1658eec67",
+		"f1bf1c30-7493-46ce-bfc8-46100bfc1e42",
+	}
+	for i, want := range golden {
+		facade := EncodeDeterministic(uint64(i), key)
+		if got := facade.String(); got != want {
+			t.Errorf("EncodeDeterministic(%d): got %s, want %s", i, got, want)
+		}
+	}
+
+	for i := range uint64(64) {
+		a := EncodeDeterministic(i, key)
+		b := EncodeDeterministic(i, key)
+		if a != b {
+			t.Fatalf("EncodeDeterministic(%d) not stable: %v != %v", i, a, b)
+		}
+		if a.Version() != 4 {
+			t.Errorf("EncodeDeterministic(%d) version: got %d, want 4", i, a.Version())
+		}
+
+		back := Decode(a, key)
+		if back.Version() != 7 {
+			t.Errorf("EncodeDeterministic(%d) decoded version: got %d, want 7", i, back.Version())
+		}
+		if ts := rd48be(back[0:6]); ts != fixtureEpochMs+i {
+			t.Errorf("EncodeDeterministic(%d) timestamp: got %d, want %d", i, ts, fixtureEpochMs+i)
+		}
+	}
+
+	if EncodeDeterministic(0, key) == EncodeDeterministic(1, key) {
+		t.Error("EncodeDeterministic should differ between indices")
+	}
+}
+
+func TestNewV4FromSeed(t *testing.T) {
+	seen := make(map[UUID]bool)
+	for seed := range uint64(256) {
+		u := NewV4FromSeed(seed)
+		if u != NewV4FromSeed(seed) {
+			t.Fatalf("NewV4FromSeed(%d) not stable", seed)
+		}
+		if u.Version() != 4 {
+			t.Errorf("NewV4FromSeed(%d) version: got %d, want 4", seed, u.Version())
+		}
+		if (u[8] & 0xC0) != 0x80 {
+			t.Errorf("NewV4FromSeed(%d) variant bits: got 0x%X, want 0x80", seed, u[8]&0xC0)
+		}
+		if seen[u] {
+			t.Errorf("NewV4FromSeed(%d) repeated an earlier UUID", seed)
+		}
+		seen[u] = true
+	}
+
+	// Golden values guard against accidental changes to the generator
+	golden := []string{
+		"7bbcb40d-5506-42d0-9e7f-e413d00cc9fd",
+		"4b46a55d-f361-4b9b-97e1-f1410e763ef4",
+	}
+	for seed, want := range golden {
+		u := NewV4FromSeed(uint64(seed))
+		if got := u.String(); got != want {
+			t.Errorf("NewV4FromSeed(%d): got %s, want %s", seed, got, want)
+		}
+	}
+}
+
+func TestRoundTripSample(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	if err := RoundTripSample(key, 1000); err != nil {
+		t.Errorf("RoundTripSample(): %v", err)
+	}
+	if err := RoundTripSample(key, 0); err != nil {
+		t.Errorf("RoundTripSample(0): %v", err)
+	}
+
+	withRandReader(t, failingReader{})
+	if err := RoundTripSample(key, 1); err == nil {
+		t.Error("RoundTripSample() should propagate read errors")
+	}
+
+	u7 := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	facade := Encode(u7, key)
+	back, _ := u7.Next()
+	msg := roundTripError(u7, facade, back, key).Error()
+	for _, want := range []string{u7.String(), facade.String(), back.String()} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("roundTripError(): got %q, want it to contain %s", msg, want)
+		}
+	}
+}
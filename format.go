@@ -0,0 +1,95 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"fmt"
+	"strings"
+)
+
+// groupBytes holds the end byte offset of each of the five canonical groups
+var groupBytes = [5]int{4, 6, 8, 10, 16}
+
+// FormatOptions controls FormatCustom. The zero value gives the canonical
+// lowercase form.
+type FormatOptions struct {
+	// UpperGroups selects uppercase hex for each of the five 8-4-4-4-12
+	// groups; index 2 holds the version and index 3 the variant
+	UpperGroups [5]bool
+	// NoDashes omits the dashes between groups
+	NoDashes bool
+	// Braces wraps the output in { and }
+	Braces bool
+}
+
+// FormatCustom formats the UUID for interop targets that need something other
+// than the canonical form, such as {018F2D9F-...} or mixed-case groups
+func (u *UUID) FormatCustom(opts FormatOptions) string {
+	buf := make([]byte, 0, 38)
+	if opts.Braces {
+		buf = append(buf, '{')
+	}
+	start := 0
+	for g, end := range groupBytes {
+		if g > 0 && !opts.NoDashes {
+			buf = append(buf, '-')
+		}
+		hexd := hexLower
+		if opts.UpperGroups[g] {
+			hexd = hexUpper
+		}
+		for _, b := range u[start:end] {
+			buf = append(buf, hexd[b>>4], hexd[b&0xF])
+		}
+		start = end
+	}
+	if opts.Braces {
+		buf = append(buf, '}')
+	}
+	return string(buf)
+}
+
+// bitLayoutRoles returns the field role of each byte for the UUID's version,
+// using the same boundaries as buildSipInputFromV7
+func bitLayoutRoles(ver int) [16]string {
+	ts, a, b := "data", "data", "data"
+	switch ver {
+	case Version7:
+		ts, a, b = "unix_ts_ms", "rand_a", "rand_b"
+	case Version4:
+		ts, a, b = "random (façade: masked unix_ts_ms)", "random", "random"
+	}
+
+	var roles [16]string
+	for i := range roles {
+		switch {
+		case i < 6:
+			roles[i] = ts
+		case i == 6:
+			roles[i] = "ver | " + a
+		case i == 7:
+			roles[i] = a
+		case i == 8:
+			roles[i] = "var | " + b
+		default:
+			roles[i] = b
+		}
+	}
+	return roles
+}
+
+// BitLayout returns a multi-line diagram of the UUID for debugging and
+// teaching: a header naming the version and variant, then one line per byte
+// with its index, hex and binary value, and its field role for the version.
+// In byte 6 the version is the high nibble; in byte 8 the RFC variant is the
+// top two bits.
+func (u *UUID) BitLayout() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: v%d (%s), variant %s\n", u.String(), u.Version(), u.VersionName(), u.VariantName())
+	for i, role := range bitLayoutRoles(u.Version()) {
+		fmt.Fprintf(&sb, "%2d  %02x  %08b  %s\n", i, u[i], u[i], role)
+	}
+	return sb.String()
+}
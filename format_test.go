@@ -0,0 +1,75 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatCustom(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	all := [5]bool{true, true, true, true, true}
+
+	tests := []struct {
+		name string
+		opts FormatOptions
+		want string
+	}{
+		{"canonical", FormatOptions{}, "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"},
+		{"upper", FormatOptions{UpperGroups: all}, "018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F"},
+		{"version-variant", FormatOptions{UpperGroups: [5]bool{2: true, 3: true}}, "018f2d9f-9a2a-7DEF-8C3F-7b1a2c4d5e6f"},
+		{"no-dashes", FormatOptions{NoDashes: true}, "018f2d9f9a2a7def8c3f7b1a2c4d5e6f"},
+		{"braces", FormatOptions{Braces: true}, "{018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f}"},
+		{"guid", FormatOptions{UpperGroups: all, Braces: true}, "{018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F}"},
+		{"braces-no-dashes", FormatOptions{NoDashes: true, Braces: true}, "{018f2d9f9a2a7def8c3f7b1a2c4d5e6f}"},
+	}
+	for _, tt := range tests {
+		if got := u.FormatCustom(tt.opts); got != tt.want {
+			t.Errorf("FormatCustom(%s): got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if got := u.FormatCustom(FormatOptions{}); got != u.String() {
+		t.Errorf("FormatCustom(zero): got %s, want String() %s", got, u.String())
+	}
+}
+
+func TestBitLayout(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	want := `018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f: v7 (Unix time-ordered), variant RFC 4122
+ 0  01  00000001  unix_ts_ms
+ 1  8f  10001111  unix_ts_ms
+ 2  2d  00101101  unix_ts_ms
+ 3  9f  10011111  unix_ts_ms
+ 4  9a  10011010  unix_ts_ms
+ 5  2a  00101010  unix_ts_ms
+ 6  7d  01111101  ver | rand_a
+ 7  ef  11101111  rand_a
+ 8  8c  10001100  var | rand_b
+ 9  3f  00111111  rand_b
+10  7b  01111011  rand_b
+11  1a  00011010  rand_b
+12  2c  00101100  rand_b
+13  4d  01001101  rand_b
+14  5e  01011110  rand_b
+15  6f  01101111  rand_b
+`
+	if got := u.BitLayout(); got != want {
+		t.Errorf("BitLayout(v7): got\n%s\nwant\n%s", got, want)
+	}
+
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade := Encode(u, key)
+	lines := strings.Split(facade.BitLayout(), "\n")
+	if !strings.Contains(lines[0], "v4 (random)") || !strings.HasSuffix(lines[1], "random (façade: masked unix_ts_ms)") || !strings.HasSuffix(lines[7], "ver | random") {
+		t.Errorf("BitLayout(v4): got\n%s", facade.BitLayout())
+	}
+
+	lines = strings.Split(Nil.BitLayout(), "\n")
+	if !strings.Contains(lines[0], "v0 (nil)") || !strings.HasSuffix(lines[9], "var | data") {
+		t.Errorf("BitLayout(Nil): got\n%s", Nil.BitLayout())
+	}
+}
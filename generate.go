@@ -0,0 +1,141 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"crypto/rand"
+	"io"
+	"time"
+)
+
+// randReader is the entropy source for UUID generation, replaceable in tests
+var randReader io.Reader = rand.Reader
+
+// timeNow is the clock for UUIDv7 generation, replaceable in tests
+var timeNow = time.Now
+
+// NewV4 returns a random UUIDv4 read from crypto/rand, with the version and
+// RFC4122 variant bits set. It returns the read error, if any.
+func NewV4() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(randReader, u[:]); err != nil {
+		return UUID{}, err
+	}
+	u.setVersion(4)
+	u.setVariantRFC4122()
+	return u, nil
+}
+
+// NewV7Exact builds a UUIDv7 from explicit field values, for reproducible
+// tests that pin the whole UUID. tsMs is the Unix millisecond timestamp and is
+// masked to 48 bits; randA is masked to 12 bits and randB to 62 bits.
+// The version and RFC variant bits are set.
+func NewV7Exact(tsMs uint64, randA uint16, randB uint64) UUID {
+	var u UUID
+	wr48be(u[0:6], tsMs&0x0000FFFFFFFFFFFF)
+	u.setVersion(7)
+	u[6] = byte((u[6] & 0xF0) | byte((randA>>8)&0x0F))
+	u[7] = byte(randA & 0xFF)
+	u.setVariantRFC4122()
+	u[8] = byte((u[8] & 0xC0) | byte((randB>>56)&0x3F))
+	for i := range 7 {
+		u[9+i] = byte((randB >> (8 * (6 - i))) & 0xFF)
+	}
+	return u
+}
+
+// ReRandomize replaces rand_a and rand_b with fresh bits from crypto/rand,
+// keeping the timestamp (bytes 0-5), the version nibble and the variant bits.
+// It breaks linkability of a UUIDv7 while preserving its creation time.
+// The UUID is left unchanged if the read fails.
+func (u *UUID) ReRandomize() error {
+	var r [10]byte
+	if _, err := io.ReadFull(randReader, r[:]); err != nil {
+		return err
+	}
+	u[6] = (u[6] & 0xF0) | (r[0] & 0x0F)
+	u[7] = r[1]
+	u[8] = (u[8] & 0xC0) | (r[2] & 0x3F)
+	copy(u[9:], r[3:])
+	return nil
+}
+
+// newV7 returns a UUIDv7 for the current time with 74 bits from crypto/rand
+func newV7() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(randReader, u[6:]); err != nil {
+		return UUID{}, err
+	}
+	wr48be(u[0:6], timeToMs(timeNow()))
+	u.setVersion(7)
+	u.setVariantRFC4122()
+	return u, nil
+}
+
+// FillV7 fills dst with UUIDv7s in strictly increasing order using a single
+// crypto/rand read of 10 bytes per element. It follows the RFC 9562 counter
+// method: the first element takes the current time and a random rand_a with
+// its top bit clear, and each next element increments rand_a, carrying into
+// the timestamp when it overflows. Fills longer than about 2048 elements per
+// millisecond therefore run ahead of the clock. rand_b is random for every
+// element. dst is left unchanged if the read fails.
+func FillV7(dst []UUID) error {
+	if len(dst) == 0 {
+		return nil
+	}
+	r := make([]byte, 10*len(dst))
+	if _, err := io.ReadFull(randReader, r); err != nil {
+		return err
+	}
+
+	// seq is the 60-bit (timestamp, rand_a) pair, as in MonotonicKey
+	seq := timeToMs(timeNow())<<12 | uint64(r[0]&0x07)<<8 | uint64(r[1])
+	for i := range dst {
+		randB := rd64le(r[10*i+2:]) & (1<<62 - 1)
+		dst[i] = NewV7Exact(seq>>12, uint16(seq&0x0FFF), randB)
+		seq++
+	}
+	return nil
+}
+
+// NewFacade generates a fresh UUIDv7 and returns only its façade under key,
+// so callers minting public IDs never hold the time-ordered plaintext. The
+// v7 lives in a local that is zeroed before returning; Go cannot guarantee
+// no copy remains in registers or on the stack, so this is best effort.
+// Decode recovers the v7 when it is needed.
+func NewFacade(key Key) (UUID, error) {
+	v7, err := newV7()
+	if err != nil {
+		return UUID{}, err
+	}
+	facade := Encode(v7, key)
+	clear(v7[:])
+	return facade, nil
+}
+
+// nodeShift is the position of the node ID within the 62-bit rand_b
+const nodeShift = 62 - 16
+
+// NewV7WithNode returns a UUIDv7 for the current time whose rand_b starts
+// with nodeID, so IDs from different generator nodes cannot collide. Only
+// 58 bits remain random (12 in rand_a, 46 in rand_b), which still leaves
+// collisions within one node and millisecond negligible. NodeID reads the
+// node back. It returns the crypto/rand read error, if any.
+func NewV7WithNode(nodeID uint16) (UUID, error) {
+	var r [8]byte
+	if _, err := io.ReadFull(randReader, r[:]); err != nil {
+		return UUID{}, err
+	}
+	x := rd64le(r[:])
+	randA := uint16(x & 0x0FFF)
+	randB := uint64(nodeID)<<nodeShift | (x>>12)&(1<<nodeShift-1)
+	return NewV7Exact(timeToMs(timeNow()), randA, randB), nil
+}
+
+// NodeID returns the node ID packed into the top 16 bits of rand_b by
+// NewV7WithNode. For other UUIDs it is just 16 random bits.
+func (u *UUID) NodeID() uint16 {
+	return uint16(randB62(u) >> nodeShift)
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// failingReader is an entropy source that always fails
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy exhausted")
+}
+
+// withRandReader swaps the entropy source for the duration of a test
+func withRandReader(t *testing.T, r io.Reader) {
+	t.Helper()
+	orig := randReader
+	randReader = r
+	t.Cleanup(func() { randReader = orig })
+}
+
+// withTimeNow pins the generation clock for the duration of a test
+func withTimeNow(t *testing.T, now time.Time) {
+	t.Helper()
+	orig := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = orig })
+}
+
+func TestNewV7Exact(t *testing.T) {
+	u := NewV7Exact(0x018f2d9f9a2a, 0x0def, 0x0c3f7b1a2c4d5e6f)
+	if got, want := u.String(), "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"; got != want {
+		t.Errorf("NewV7Exact(): got %s, want %s", got, want)
+	}
+
+	// Out-of-range inputs are masked to 48, 12 and 62 bits
+	masked := NewV7Exact(0xFFFF018f2d9f9a2a, 0xFdef, 0xCc3f7b1a2c4d5e6f)
+	if masked != u {
+		t.Errorf("NewV7Exact() masking: got %v, want %v", masked, u)
+	}
+	if masked.Version() != 7 || (masked[8]&0xC0) != 0x80 {
+		t.Errorf("NewV7Exact() version/variant: got %d/0x%X", masked.Version(), masked[8]&0xC0)
+	}
+}
+
+func TestNewV4(t *testing.T) {
+	seen := make(map[UUID]bool)
+	for range 100 {
+		u, err := NewV4()
+		if err != nil {
+			t.Fatalf("NewV4() error: %v", err)
+		}
+		if u.Version() != 4 {
+			t.Errorf("NewV4() version: got %d, want 4", u.Version())
+		}
+		if (u[8] & 0xC0) != 0x80 {
+			t.Errorf("NewV4() variant bits: got 0x%X, want 0x80", u[8]&0xC0)
+		}
+		if seen[u] {
+			t.Fatalf("NewV4() repeated %v", u)
+		}
+		seen[u] = true
+	}
+}
+
+func TestNewV4ReadError(t *testing.T) {
+	withRandReader(t, failingReader{})
+	u, err := NewV4()
+	if err == nil {
+		t.Fatal("NewV4() should propagate read errors")
+	}
+	if !u.IsZero() {
+		t.Errorf("NewV4() on error: got %v, want zero UUID", u)
+	}
+}
+
+func TestReRandomize(t *testing.T) {
+	orig := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+
+	u := orig
+	if err := u.ReRandomize(); err != nil {
+		t.Fatalf("ReRandomize() error: %v", err)
+	}
+	if !u.AsV7Time().Equal(orig.AsV7Time()) {
+		t.Errorf("ReRandomize() changed the time: got %v, want %v", u.AsV7Time(), orig.AsV7Time())
+	}
+	if [6]byte(u[0:6]) != [6]byte(orig[0:6]) {
+		t.Errorf("ReRandomize() changed the timestamp bytes")
+	}
+	if u.Version() != 7 || (u[8]&0xC0) != 0x80 {
+		t.Errorf("ReRandomize() version/variant: got %d/0x%X", u.Version(), u[8]&0xC0)
+	}
+	if randA12(&u) == randA12(&orig) && randB62(&u) == randB62(&orig) {
+		t.Error("ReRandomize() should change the random bits")
+	}
+
+	withRandReader(t, failingReader{})
+	before := u
+	if err := u.ReRandomize(); err == nil {
+		t.Error("ReRandomize() should propagate read errors")
+	}
+	if u != before {
+		t.Error("ReRandomize() should not modify the UUID on error")
+	}
+}
+
+func TestNewV7WithNode(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	withTimeNow(t, now)
+
+	for _, node := range []uint16{0, 1, 0x1234, 0xFFFF} {
+		u, err := NewV7WithNode(node)
+		if err != nil {
+			t.Fatalf("NewV7WithNode(%d) error: %v", node, err)
+		}
+		if got := u.NodeID(); got != node {
+			t.Errorf("NewV7WithNode(%d).NodeID(): got %d", node, got)
+		}
+		if !u.AsV7Time().Equal(now) {
+			t.Errorf("NewV7WithNode(%d) time: got %v, want %v", node, u.AsV7Time(), now)
+		}
+		if u.Version() != 7 || (u[8]&0xC0) != 0x80 {
+			t.Errorf("NewV7WithNode(%d) version/variant: got %d/0x%X", node, u.Version(), u[8]&0xC0)
+		}
+	}
+
+	// The remaining bits stay random
+	a, _ := NewV7WithNode(7)
+	b, _ := NewV7WithNode(7)
+	if a == b {
+		t.Error("NewV7WithNode() should fill the remaining bits randomly")
+	}
+
+	// Every random bit lands below the node ID
+	withRandReader(t, bytes.NewReader(bytes.Repeat([]byte{0xFF}, 8)))
+	u, err := NewV7WithNode(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if randA12(&u) != 0x0FFF || randB62(&u) != 1<<46-1 {
+		t.Errorf("NewV7WithNode(0) random bits: got 0x%X, 0x%X", randA12(&u), randB62(&u))
+	}
+
+	withRandReader(t, failingReader{})
+	if u, err := NewV7WithNode(1); err == nil || !u.IsZero() {
+		t.Errorf("NewV7WithNode() with failing reader: got %v, %v", u, err)
+	}
+}
+
+func TestNewFacade(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	withTimeNow(t, now)
+
+	seen := make(map[UUID]bool)
+	for range 100 {
+		facade, err := NewFacade(key)
+		if err != nil {
+			t.Fatalf("NewFacade() error: %v", err)
+		}
+		if facade.Version() != 4 || (facade[8]&0xC0) != 0x80 {
+			t.Errorf("NewFacade() version/variant: got %d/0x%X", facade.Version(), facade[8]&0xC0)
+		}
+		v7 := Decode(facade, key)
+		if !v7.AsV7Time().Equal(now) {
+			t.Errorf("NewFacade() decoded time: got %v, want %v", v7.AsV7Time(), now)
+		}
+		if seen[facade] {
+			t.Fatal("NewFacade() repeated a façade")
+		}
+		seen[facade] = true
+	}
+
+	withRandReader(t, failingReader{})
+	if u, err := NewFacade(key); err == nil || !u.IsZero() {
+		t.Errorf("NewFacade() with failing reader: got %v, %v", u, err)
+	}
+}
+
+func TestFillV7(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	withTimeNow(t, now)
+
+	dst := make([]UUID, 5000)
+	if err := FillV7(dst); err != nil {
+		t.Fatalf("FillV7() error: %v", err)
+	}
+	if !dst[0].AsV7Time().Equal(now) {
+		t.Errorf("FillV7()[0] time: got %v, want %v", dst[0].AsV7Time(), now)
+	}
+	if randA12(&dst[0]) >= 0x0800 {
+		t.Errorf("FillV7()[0] rand_a: got 0x%X, want top bit clear", randA12(&dst[0]))
+	}
+	for i := range dst {
+		if dst[i].Version() != 7 || (dst[i][8]&0xC0) != 0x80 {
+			t.Fatalf("FillV7()[%d] version/variant: got %d/0x%X", i, dst[i].Version(), dst[i][8]&0xC0)
+		}
+		if i > 0 {
+			if !dst[i-1].Before(dst[i]) || bytes.Compare(dst[i-1][:], dst[i][:]) >= 0 {
+				t.Fatalf("FillV7()[%d] should order after [%d]", i, i-1)
+			}
+			if dst[i].MonotonicKey() != dst[i-1].MonotonicKey()+1 {
+				t.Fatalf("FillV7()[%d] should increment the counter", i)
+			}
+		}
+	}
+	// 5000 elements overflow rand_a at least once
+	if !dst[len(dst)-1].AsV7Time().After(now) {
+		t.Error("FillV7() should carry rand_a into the timestamp")
+	}
+	if randB62(&dst[0]) == randB62(&dst[1]) {
+		t.Error("FillV7() should fill rand_b randomly")
+	}
+
+	if err := FillV7(nil); err != nil {
+		t.Errorf("FillV7(nil): %v", err)
+	}
+
+	withRandReader(t, failingReader{})
+	keep := []UUID{Max}
+	if err := FillV7(keep); err == nil || keep[0] != Max {
+		t.Errorf("FillV7() with failing reader: got %v, %v", keep[0], err)
+	}
+}
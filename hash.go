@@ -0,0 +1,136 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"hash/crc32"
+	"math/bits"
+)
+
+// fixedKey is the public SipHash key for key-free hashing of UUID bytes.
+// It is not a secret; it only makes the hashes stable across processes.
+var fixedKey = Key{K0: 0x5d1f8a3c9e6b2047, K1: 0xa4c2e7f0138b96d5}
+
+// ShouldSample reports whether the UUID falls within a sample of the given
+// rate (0.0 to 1.0). The decision is the SipHash-2-4 of the 16 bytes under key,
+// normalized to [0,1), so it is stable per UUID across services sharing the
+// key and unpredictable without it.
+func (u *UUID) ShouldSample(rate float64, key Key) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	h := siphash24(u[:], key.K0, key.K1)
+	return float64(h>>11)/(1<<53) < rate
+}
+
+// Shard returns a shard index in [0, numShards) derived from a SipHash-2-4 of
+// the 16 UUID bytes under a fixed public key, so routers can shard without
+// holding the façade secret. It hashes whatever bytes are present, so a v4
+// façade and its v7 land on different shards. It returns 0 if numShards is
+// not positive.
+func (u *UUID) Shard(numShards int) int {
+	if numShards <= 0 {
+		return 0
+	}
+	return int(siphash24(u[:], fixedKey.K0, fixedKey.K1) % uint64(numShards))
+}
+
+// WouldCollide reports whether a and b land on the same shard, using the
+// exact Shard function the router uses, so capacity analysis and runtime
+// cannot drift apart
+func WouldCollide(a, b UUID, numShards int) bool {
+	return a.Shard(numShards) == b.Shard(numShards)
+}
+
+// RingHash returns the full 64-bit SipHash-2-4 of the 16 UUID bytes under
+// key, for placing the UUID on a consistent-hash ring. Unlike Shard, which
+// reduces a key-free hash to a bucket index, the raw keyed value is returned
+// for the caller to place.
+func (u *UUID) RingHash(key Key) uint64 {
+	return siphash24(u[:], key.K0, key.K1)
+}
+
+// Truncate64 returns a 64-bit fingerprint of the UUID for indexes that only
+// hold 8 bytes: the SipHash-2-4 of the 16 bytes under the fixed public key.
+// It is lossy and cannot be reversed, and distinct UUIDs collide with
+// probability about n²/2⁶⁵ over n IDs, so keep the full UUID as the source
+// of truth.
+func (u *UUID) Truncate64() uint64 {
+	return siphash24(u[:], fixedKey.K0, fixedKey.K1)
+}
+
+// RangeShard returns which of n equal, contiguous ranges of the 128-bit UUID
+// space holds u, in [0, n). Unlike Shard, which hashes and scatters
+// neighbouring UUIDs, RangeShard preserves order: shard i owns one unbroken
+// key range, so a range scan touches only the shards it overlaps. Balance
+// depends on the UUIDs being uniform in their high bits, as façades are;
+// UUIDv7s cluster by creation time and would pile into few shards. The shard
+// is the exact floor(u*n / 2^128) over all 128 bits. It returns 0 if n is not
+// positive.
+func (u *UUID) RangeShard(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	hi, mid := bits.Mul64(rd64be(u[0:8]), uint64(n))
+	carry, _ := bits.Mul64(rd64be(u[8:16]), uint64(n))
+	_, c := bits.Add64(mid, carry, 0)
+	return int(hi + c)
+}
+
+// Hashes returns k hash values of the 16 UUID bytes for a Bloom or cuckoo
+// filter, by double hashing: h_i = h1 + i*h2. h1 is the SipHash-2-4 under key
+// and h2 the SipHash-2-4 under key with K1 tweaked, forced odd so the
+// sequence does not repeat early. It returns nil if k is not positive.
+func (u *UUID) Hashes(k int, key Key) []uint64 {
+	if k <= 0 {
+		return nil
+	}
+	h1 := siphash24(u[:], key.K0, key.K1)
+	h2 := siphash24(u[:], key.K0, key.K1^0x9e3779b97f4a7c15) | 1
+
+	out := make([]uint64, k)
+	for i := range out {
+		out[i] = h1 + uint64(i)*h2
+	}
+	return out
+}
+
+// ColorHint returns a stable display color for the UUID, so every UI shows
+// the same color for the same ID. It takes the top 24 bits of a SipHash-2-4
+// of the 16 bytes under the fixed public key; it is not secret and, like
+// Shard, differs between a façade and its v7.
+func (u *UUID) ColorHint() (r, g, b uint8) {
+	h := siphash24(u[:], fixedKey.K0, fixedKey.K1)
+	return uint8(h >> 56), uint8(h >> 48), uint8(h >> 40)
+}
+
+// Pseudonym returns a keyed, one-way replacement for the UUID, for analytics
+// that must join on IDs without learning them. Each half is a SipHash-2-4 of
+// all 16 bytes plus a domain byte under key, with version 4 and the RFC
+// variant set, so the same UUID and key always give the same pseudonym.
+// Unlike Encode it transforms every byte and cannot be reversed.
+func (u *UUID) Pseudonym(key Key) UUID {
+	var msg [17]byte
+	copy(msg[:], u[:])
+	st := newSipState(key.K0, key.K1)
+
+	var out UUID
+	wr64be(out[0:8], st.sum(msg[:]))
+	msg[16] = 1
+	wr64be(out[8:16], st.sum(msg[:]))
+	out.setVersion(4)
+	out.setVariantRFC4122()
+	return out
+}
+
+// CRC32 returns the IEEE CRC-32 of the 16 UUID bytes, for frame checksums.
+// It only catches accidental corruption; unlike Fingerprint or the SipHash
+// based helpers it is trivially forgeable.
+func (u *UUID) CRC32() uint32 {
+	return crc32.ChecksumIEEE(u[:])
+}
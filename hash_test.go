@@ -0,0 +1,312 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestShouldSample(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+
+	const n = 10000
+	sampled := 0
+	for range n {
+		u := NewV7Exact(rng.next(), uint16(rng.next()), rng.next())
+		if u.ShouldSample(0.25, key) {
+			sampled++
+		}
+		if u.ShouldSample(0.25, key) != u.ShouldSample(0.25, key) {
+			t.Fatal("ShouldSample() should be stable per UUID")
+		}
+		if u.ShouldSample(0, key) {
+			t.Error("ShouldSample(0) should never sample")
+		}
+		if !u.ShouldSample(1, key) {
+			t.Error("ShouldSample(1) should always sample")
+		}
+	}
+
+	// Expect ~2500 with a generous tolerance
+	if sampled < 2200 || sampled > 2800 {
+		t.Errorf("ShouldSample(0.25): sampled %d of %d", sampled, n)
+	}
+}
+
+func TestShard(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+
+	const n, shards = 16000, 16
+	var counts [shards]int
+	for range n {
+		facade := Encode(NewV7Exact(rng.next(), uint16(rng.next()), rng.next()), key)
+		s := facade.Shard(shards)
+		if s < 0 || s >= shards {
+			t.Fatalf("Shard(%d): got %d, out of range", shards, s)
+		}
+		if facade.Shard(shards) != s {
+			t.Fatal("Shard() should be stable per UUID")
+		}
+		counts[s]++
+	}
+
+	// Expect ~1000 per shard with a generous tolerance
+	for i, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Errorf("Shard(%d): shard %d got %d of %d", shards, i, c, n)
+		}
+	}
+
+	var u UUID
+	if u.Shard(0) != 0 || u.Shard(-3) != 0 || u.Shard(1) != 0 {
+		t.Error("Shard() should return 0 for numShards <= 1")
+	}
+}
+
+func TestHashes(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+
+	hs := u.Hashes(8, key)
+	if len(hs) != 8 {
+		t.Fatalf("Hashes(8): got %d values, want 8", len(hs))
+	}
+	if hs[0] != siphash24(u[:], key.K0, key.K1) {
+		t.Errorf("Hashes()[0]: got 0x%X, want the SipHash of the UUID", hs[0])
+	}
+	step := hs[1] - hs[0]
+	if step&1 == 0 {
+		t.Errorf("Hashes() step should be odd: got 0x%X", step)
+	}
+	seen := make(map[uint64]bool)
+	for i, h := range hs {
+		if h != hs[0]+uint64(i)*step {
+			t.Errorf("Hashes()[%d]: got 0x%X, want h1 + %d*h2", i, h, i)
+		}
+		if seen[h] {
+			t.Errorf("Hashes()[%d] repeats an earlier value", i)
+		}
+		seen[h] = true
+	}
+
+	// Stable per UUID and key, different for another key or UUID
+	if again := u.Hashes(8, key); again[7] != hs[7] {
+		t.Error("Hashes() should be deterministic")
+	}
+	other := Key{K0: key.K1, K1: key.K0}
+	if u.Hashes(1, other)[0] == hs[0] {
+		t.Error("Hashes() should depend on the key")
+	}
+	v := NewV7Exact(1704067200001, 0x0ABC, 0x0123456789ABCDEF)
+	if v.Hashes(1, key)[0] == hs[0] {
+		t.Error("Hashes() should depend on the UUID")
+	}
+
+	for _, k := range []int{0, -1} {
+		if got := u.Hashes(k, key); got != nil {
+			t.Errorf("Hashes(%d): got %v, want nil", k, got)
+		}
+	}
+}
+
+func TestRangeShard(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		n    int
+		want int
+	}{
+		{"nil", Nil, 4, 0},
+		{"max", Max, 4, 3},
+		{"first-quarter-end", U128{Hi: 1<<62 - 1, Lo: ^uint64(0)}.UUID(), 4, 0},
+		{"second-quarter-start", U128{Hi: 1 << 62}.UUID(), 4, 1},
+		{"half", U128{Hi: 1 << 63}.UUID(), 3, 1},
+		{"third-boundary-low", U128{Hi: 0x5555555555555555, Lo: 0x5555555555555554}.UUID(), 3, 0},
+		{"third-boundary", U128{Hi: 0x5555555555555555, Lo: 0x5555555555555556}.UUID(), 3, 1},
+		{"third-boundary-max-lo", U128{Hi: 0x5555555555555555, Lo: ^uint64(0)}.UUID(), 3, 1},
+		{"single", Max, 1, 0},
+		{"zero-n", Max, 0, 0},
+		{"negative-n", Max, -2, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.u.RangeShard(tt.n); got != tt.want {
+			t.Errorf("RangeShard(%s): got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// Shards are monotonic in UUID order
+	prev := 0
+	for i := range 256 {
+		u := U128{Hi: uint64(i) << 56}.UUID()
+		s := u.RangeShard(7)
+		if s < prev || s >= 7 {
+			t.Fatalf("RangeShard(%v): got %d after %d", u, s, prev)
+		}
+		prev = s
+	}
+	if prev != 6 {
+		t.Errorf("RangeShard() highest shard: got %d, want 6", prev)
+	}
+
+	// Matches the exact 128-bit floor(u*n / 2^128)
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+	for range 1000 {
+		u := U128{Hi: rng.next(), Lo: rng.next()}.UUID()
+		n := int(rng.next()%1000) + 1
+		want := new(big.Int).SetBytes(u[:])
+		want.Mul(want, big.NewInt(int64(n))).Rsh(want, 128)
+		if got := u.RangeShard(n); int64(got) != want.Int64() {
+			t.Fatalf("RangeShard(%v, %d): got %d, want %d", u, n, got, want)
+		}
+	}
+}
+
+func TestColorHint(t *testing.T) {
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	r, g, b := u.ColorHint()
+
+	h := siphash24(u[:], fixedKey.K0, fixedKey.K1)
+	if r != uint8(h>>56) || g != uint8(h>>48) || b != uint8(h>>40) {
+		t.Errorf("ColorHint(): got (%d, %d, %d), want the top bytes of 0x%X", r, g, b, h)
+	}
+	if r2, g2, b2 := u.ColorHint(); r2 != r || g2 != g || b2 != b {
+		t.Error("ColorHint() should be deterministic")
+	}
+
+	// Neighbouring IDs spread over many colors
+	seen := make(map[[3]uint8]bool)
+	for i := range 256 {
+		v := NewV7Exact(1704067200000, uint16(i), 0)
+		r, g, b := v.ColorHint()
+		seen[[3]uint8{r, g, b}] = true
+	}
+	if len(seen) < 250 {
+		t.Errorf("ColorHint() produced only %d distinct colors for 256 IDs", len(seen))
+	}
+}
+
+func TestPseudonym(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	other := Key{K0: key.K1, K1: key.K0}
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+
+	p := u.Pseudonym(key)
+	if p != u.Pseudonym(key) {
+		t.Error("Pseudonym() should be deterministic")
+	}
+	if p.Version() != 4 || (p[8]&0xC0) != 0x80 {
+		t.Errorf("Pseudonym() version/variant: got %d/0x%X", p.Version(), p[8]&0xC0)
+	}
+	if p == Encode(u, key) || [8]byte(p[8:]) == [8]byte(u[8:]) {
+		t.Error("Pseudonym() should transform every byte, unlike Encode")
+	}
+	if p == u.Pseudonym(other) {
+		t.Error("Pseudonym() should depend on the key")
+	}
+
+	// Neighbouring IDs get unrelated pseudonyms
+	seen := map[UUID]bool{p: true}
+	for i := range uint16(255) {
+		v := NewV7Exact(1704067200000, i, 0x0123456789ABCDEF)
+		q := v.Pseudonym(key)
+		if seen[q] && v != u {
+			t.Fatalf("Pseudonym() collision for rand_a %d", i)
+		}
+		seen[q] = true
+	}
+}
+
+func TestRingHash(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+
+	h := u.RingHash(key)
+	if h != siphash24(u[:], key.K0, key.K1) {
+		t.Errorf("RingHash(): got 0x%X, want the SipHash of the UUID", h)
+	}
+	if h != u.Hashes(1, key)[0] {
+		t.Error("RingHash() should equal the first of Hashes()")
+	}
+	if h == u.RingHash(Key{K0: key.K1, K1: key.K0}) {
+		t.Error("RingHash() should depend on the key")
+	}
+	v := Encode(u, key)
+	if h == v.RingHash(key) {
+		t.Error("RingHash() should differ between a v7 and its façade")
+	}
+}
+
+func TestTruncate64(t *testing.T) {
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	h := u.Truncate64()
+	if h != siphash24(u[:], fixedKey.K0, fixedKey.K1) {
+		t.Errorf("Truncate64(): got 0x%X, want the fixed-key SipHash", h)
+	}
+	if h != u.Truncate64() {
+		t.Error("Truncate64() should be deterministic")
+	}
+
+	seen := make(map[uint64]bool)
+	for i := range uint64(1000) {
+		v := NewV7Exact(1704067200000+i, 0, 0)
+		th := v.Truncate64()
+		if seen[th] {
+			t.Fatalf("Truncate64() collision at %d", i)
+		}
+		seen[th] = true
+	}
+}
+
+func TestWouldCollide(t *testing.T) {
+	ids := make([]UUID, 64)
+	for i := range ids {
+		ids[i] = NewV7Exact(1704067200000+uint64(i), uint16(i), 0)
+	}
+
+	collisions := 0
+	for i := range ids {
+		for j := range ids {
+			want := ids[i].Shard(8) == ids[j].Shard(8)
+			if got := WouldCollide(ids[i], ids[j], 8); got != want {
+				t.Errorf("WouldCollide(%d, %d): got %v, want %v", i, j, got, want)
+			}
+			if i != j && want {
+				collisions++
+			}
+		}
+		if !WouldCollide(ids[i], ids[i], 8) {
+			t.Errorf("WouldCollide(%d, %d) should be true", i, i)
+		}
+	}
+	if collisions == 0 {
+		t.Error("WouldCollide() found no collisions among 64 IDs in 8 shards")
+	}
+
+	// Every pair shares the single shard Shard returns for n <= 1
+	if !WouldCollide(ids[0], ids[1], 1) || !WouldCollide(ids[0], ids[1], 0) {
+		t.Error("WouldCollide() should be true when there is at most one shard")
+	}
+}
+
+func TestCRC32(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	tests := []struct {
+		name string
+		u    UUID
+		want uint32
+	}{
+		{"v7", u, 0x6f257fac},
+		{"nil", Nil, 0xecbb4b55},
+		{"max", Max, 0x3fb3c61a},
+	}
+	for _, tt := range tests {
+		if got := tt.u.CRC32(); got != tt.want {
+			t.Errorf("CRC32(%s): got 0x%08x, want 0x%08x", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// fingerprintMsg is the fixed message hashed under a key by Fingerprint
+var fingerprintMsg = []byte("uuid47-key-fingerprint")
+
+// weakKeys are well-known keys that must never be used in production:
+// the key from the package documentation and the SipHash reference test key
+var weakKeys = []Key{
+	{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210},
+	{K0: 0x0706050403020100, K1: 0x0f0e0d0c0b0a0908},
+}
+
+// MaskBits returns the number of timestamp bits protected by the façade mask.
+// Encode masks the full 48-bit millisecond timestamp of a UUIDv7; the random
+// bits are carried over unchanged.
+func MaskBits() int {
+	return 48
+}
+
+// Fingerprint returns a non-secret 32-bit identifier for the key, suitable for
+// detecting configuration drift between instances. It is the SipHash-2-4 of a
+// fixed message under the key, so it reveals nothing useful about the key.
+func (k Key) Fingerprint() uint32 {
+	return uint32(siphash24(fingerprintMsg, k.K0, k.K1) >> 32)
+}
+
+// IsWeak reports whether the key is trivially guessable: both halves equal
+// (including the zero key), or one of the well-known example keys. A weak key
+// yields a predictable mask and leaks timestamps, so startup validation should
+// refuse to use one.
+func (k Key) IsWeak() bool {
+	if k.K0 == k.K1 {
+		return true
+	}
+	for _, w := range weakKeys {
+		if k == w {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateKeys checks a key set loaded at startup. Every weak key (see
+// IsWeak) and every repeat of an earlier key, which would make key ring
+// lookups ambiguous, is reported as an errors.Join of per-entry errors naming
+// the index and wrapping ErrWeakKey or ErrDuplicateKey. Key material is never
+// included in the messages. It returns nil for a valid set.
+func ValidateKeys(keys []Key) error {
+	var errs []error
+	first := make(map[Key]int, len(keys))
+	for i, k := range keys {
+		if k.IsWeak() {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, ErrWeakKey))
+		}
+		if j, ok := first[k]; ok {
+			errs = append(errs, fmt.Errorf("index %d (same as index %d): %w", i, j, ErrDuplicateKey))
+			continue
+		}
+		first[k] = i
+	}
+	return errors.Join(errs...)
+}
+
+// EpochKey derives the sub-key for an epoch from a master key, using
+// SipHash-2-4 of the epoch number under the master key. Compromising one
+// epoch key does not reveal the master key or any other epoch key.
+//
+// A typical pattern derives the epoch from the UUIDv7 timestamp when encoding
+// (e.g. epoch = ts / 30 days) and uses EpochKey(master, epoch) with Encode.
+// The decoder cannot recover the epoch from the façade itself, since the
+// timestamp is masked by the very key being chosen, so the epoch must travel
+// out-of-band (alongside the façade, like a key ID) or be bounded so the
+// decoder can try the few candidate epochs and keep the plausible result.
+func EpochKey(master Key, epoch uint32) Key {
+	var msg [5]byte
+	msg[0] = byte(epoch)
+	msg[1] = byte(epoch >> 8)
+	msg[2] = byte(epoch >> 16)
+	msg[3] = byte(epoch >> 24)
+
+	var k Key
+	k.K0 = siphash24(msg[:], master.K0, master.K1)
+	msg[4] = 1
+	k.K1 = siphash24(msg[:], master.K0, master.K1)
+	return k
+}
+
+// KeyRing is a set of keys, such as one per tenant
+type KeyRing []Key
+
+// Identify finds the key a façade was encoded with. Each key decodes the
+// façade and a result is plausible when its timestamp is not later than
+// now+maxSkew. A wrong key decodes to a uniformly random 48-bit timestamp,
+// which is plausible with probability about (now+maxSkew)/2^48 ms, roughly
+// 0.6% today, so with n keys a façade is ambiguous about n*0.6% of the time.
+// Identify only answers when exactly one key is plausible; it returns false
+// if none or several are, rather than guess. Every key is tried even after a
+// match, so the time taken does not reveal which key owns the façade.
+func (r KeyRing) Identify(facade UUID, now time.Time, maxSkew time.Duration) (Key, UUID, bool) {
+	limit := timeToMs(now.Add(maxSkew))
+
+	var (
+		match     Key
+		plausible int
+	)
+	for _, k := range r {
+		if decodeTimestamp(&facade, k) <= limit {
+			match = k
+			plausible++
+		}
+	}
+	if plausible != 1 {
+		return Key{}, UUID{}, false
+	}
+	return match, Decode(facade, match), true
+}
+
+// KeySchedule holds the SipHash-2-4 state precomputed from a key, so each
+// Encode or Decode skips the key initialization. That saves only four XORs
+// per call; see BenchmarkKeyScheduleEncode against BenchmarkEncode.
+type KeySchedule struct {
+	state sipState
+}
+
+// NewKeySchedule precomputes the key schedule for key
+func NewKeySchedule(key Key) *KeySchedule {
+	return &KeySchedule{state: newSipState(key.K0, key.K1)}
+}
+
+// timestampMask derives the 48-bit timestamp mask from the random bits of u
+func (ks *KeySchedule) timestampMask(u *UUID) uint64 {
+	var sipmsg [10]byte
+	buildSipInputFromV7(u, &sipmsg)
+	return ks.state.sum(sipmsg[:]) & 0x0000FFFFFFFFFFFF
+}
+
+// Encode encodes a UUIDv7 as a UUIDv4 façade, like Encode with the scheduled key
+func (ks *KeySchedule) Encode(v7 UUID) UUID {
+	return applyMask(v7, ks.timestampMask(&v7), 4)
+}
+
+// Decode decodes a UUIDv4 façade back to UUIDv7, like Decode with the scheduled key
+func (ks *KeySchedule) Decode(v4facade UUID) UUID {
+	return applyMask(v4facade, ks.timestampMask(&v4facade), 7)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaskBits(t *testing.T) {
+	if MaskBits() != 48 {
+		t.Fatalf("MaskBits(): got %d, want 48", MaskBits())
+	}
+
+	// Encode must change exactly the bits reported by MaskBits plus the version nibble
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u7 := NewV7Exact(0x123456789ABC, 0x0ABC, 0x0123456789ABCDEF)
+	facade := Encode(u7, key)
+	if facade[6]&0x0F != u7[6]&0x0F || [9]byte(facade[7:]) != [9]byte(u7[7:]) {
+		t.Errorf("Encode changed bits outside the %d-bit timestamp", MaskBits())
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	k1 := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	k2 := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543211}
+
+	if k1.Fingerprint() != k1.Fingerprint() {
+		t.Error("Fingerprint() should be stable")
+	}
+	if k1.Fingerprint() == k2.Fingerprint() {
+		t.Error("Fingerprint() should differ between keys")
+	}
+	if fp := k1.Fingerprint(); uint64(fp) == k1.K0&0xFFFFFFFF || uint64(fp) == k1.K0>>32 {
+		t.Error("Fingerprint() should not expose key material")
+	}
+}
+
+func TestIsWeak(t *testing.T) {
+	tests := []struct {
+		name string
+		key  Key
+		want bool
+	}{
+		{"zero", Key{}, true},
+		{"ones", Key{K0: ^uint64(0), K1: ^uint64(0)}, true},
+		{"equal-halves", Key{K0: 0x1234, K1: 0x1234}, true},
+		{"example", Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}, true},
+		{"siphash-reference", Key{K0: 0x0706050403020100, K1: 0x0f0e0d0c0b0a0908}, true},
+		{"random", Key{K0: 0x9c4b1e2f7a3d5860, K1: 0x1f7e3a9b2c6d4e05}, false},
+		{"half-zero", Key{K0: 0, K1: 0x1f7e3a9b2c6d4e05}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.key.IsWeak(); got != tt.want {
+			t.Errorf("IsWeak(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEpochKey(t *testing.T) {
+	master := Key{K0: 0x9c4b1e2f7a3d5860, K1: 0x1f7e3a9b2c6d4e05}
+	const epochMs = 30 * 24 * 60 * 60 * 1000
+
+	u7 := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	epoch := uint32(1704067200000 / epochMs)
+
+	k := EpochKey(master, epoch)
+	if k != EpochKey(master, epoch) {
+		t.Fatal("EpochKey() should be deterministic")
+	}
+	if k == master || k.K0 == k.K1 {
+		t.Errorf("EpochKey() should derive an independent key: got %+v", k)
+	}
+	if k == EpochKey(master, epoch+1) {
+		t.Error("EpochKey() should differ between epochs")
+	}
+	if k == EpochKey(Key{K0: master.K0, K1: master.K1 ^ 1}, epoch) {
+		t.Error("EpochKey() should differ between master keys")
+	}
+
+	facade := Encode(u7, k)
+	if back := Decode(facade, EpochKey(master, epoch)); back != u7 {
+		t.Errorf("EpochKey() roundtrip failed: got %v, want %v", back, u7)
+	}
+	if back := Decode(facade, EpochKey(master, epoch-1)); back == u7 {
+		t.Error("Decoding with the wrong epoch key should produce a different result")
+	}
+}
+
+func TestKeyRingIdentify(t *testing.T) {
+	ring := make(KeyRing, 32)
+	state := uint64(42)
+	for i := range ring {
+		ring[i] = Key{K0: splitmix64(&state), K1: splitmix64(&state)}
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limit := uint64(now.Add(time.Minute).UnixMilli())
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+	for i := range 256 {
+		created := now.Add(-time.Duration(rng.next()%(90*24)) * time.Hour)
+		u7 := NewV7Exact(uint64(created.UnixMilli()), uint16(rng.next()), rng.next())
+		owner := ring[i%len(ring)]
+		facade := Encode(u7, owner)
+
+		plausible := 0
+		for _, k := range ring {
+			if decodeTimestamp(&facade, k) <= limit {
+				plausible++
+			}
+		}
+
+		k, got, ok := ring.Identify(facade, now, time.Minute)
+		if ok != (plausible == 1) {
+			t.Fatalf("Identify(%d): got ok %v with %d plausible keys", i, ok, plausible)
+		}
+		if ok && (k != owner || got != u7) {
+			t.Errorf("Identify(%d): got key %+v and %v, want %+v and %v", i, k, got, owner, u7)
+		}
+	}
+
+	// A wrong key that also decodes to a plausible time makes the façade
+	// ambiguous, even though the owner's timestamp is closer to now
+	u7 := NewV7Exact(uint64(now.Add(-time.Hour).UnixMilli()), 1, 2)
+	facade := Encode(u7, ring[0])
+	var decoy Key
+	for {
+		decoy = Key{K0: splitmix64(&state), K1: splitmix64(&state)}
+		if decodeTimestamp(&facade, decoy) <= limit {
+			break
+		}
+	}
+	if _, _, ok := (KeyRing{ring[0], decoy}).Identify(facade, now, time.Minute); ok {
+		t.Error("Identify() should fail when several keys are plausible")
+	}
+	if k, _, ok := (KeyRing{ring[0]}).Identify(facade, now, time.Minute); !ok || k != ring[0] {
+		t.Errorf("Identify(owner only): got %+v, %v, want %+v, true", k, ok, ring[0])
+	}
+
+	// A façade made far in the future is implausible under every key
+	future := NewV7Exact(uint64(now.Add(24*time.Hour).UnixMilli()), 1, 2)
+	if _, _, ok := ring[:1].Identify(Encode(future, ring[0]), now, time.Minute); ok {
+		t.Error("Identify() should reject timestamps beyond maxSkew")
+	}
+
+	// A limit before 1970 clamps to zero instead of wrapping to accept all
+	before := time.Unix(0, 0).Add(-time.Hour)
+	if _, _, ok := ring[:1].Identify(Encode(u7, ring[0]), before, time.Minute); ok {
+		t.Error("Identify() should reject everything when now+maxSkew is before 1970")
+	}
+
+	if _, _, ok := KeyRing(nil).Identify(UUID{}, now, time.Minute); ok {
+		t.Error("Identify() on an empty ring should fail")
+	}
+}
+
+func TestKeySchedule(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	ks := NewKeySchedule(key)
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+
+	for range 256 {
+		u7 := NewV7Exact(rng.next(), uint16(rng.next()), rng.next())
+		facade := ks.Encode(u7)
+		if want := Encode(u7, key); facade != want {
+			t.Fatalf("KeySchedule.Encode(%v): got %v, want %v", u7, facade, want)
+		}
+		if back := ks.Decode(facade); back != u7 {
+			t.Fatalf("KeySchedule.Decode(%v): got %v, want %v", facade, back, u7)
+		}
+	}
+}
+
+func TestValidateKeys(t *testing.T) {
+	a := Key{K0: 0x5d1f8a3c9e6b2047, K1: 0xa4c2e7f0138b96d5}
+	b := Key{K0: 0x243f6a8885a308d3, K1: 0x13198a2e03707344}
+	weak := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	if err := ValidateKeys([]Key{a, b}); err != nil {
+		t.Errorf("ValidateKeys(valid): got %v, want nil", err)
+	}
+	if err := ValidateKeys(nil); err != nil {
+		t.Errorf("ValidateKeys(nil): got %v, want nil", err)
+	}
+
+	err := ValidateKeys([]Key{a, weak, b, a, {}, a})
+	if err == nil {
+		t.Fatal("ValidateKeys() should fail for weak and duplicate keys")
+	}
+	if !errors.Is(err, ErrWeakKey) || !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("ValidateKeys() should wrap ErrWeakKey and ErrDuplicateKey: %v", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"index 1: ", "index 3 (same as index 0): ", "index 4: ", "index 5 (same as index 0): "} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("ValidateKeys() error should mention %q: %v", want, msg)
+		}
+	}
+	if strings.Contains(msg, "index 2") {
+		t.Errorf("ValidateKeys() should not report valid entries: %v", msg)
+	}
+	if strings.Contains(msg, "5d1f8a3c") {
+		t.Errorf("ValidateKeys() error should not include key material: %v", msg)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 4 {
+		t.Errorf("ValidateKeys(): got %d errors, want 4", n)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+// Masker is a keyed function from the 10-byte façade message (the random
+// bits, as built by Encode) to a 64-bit value whose low 48 bits mask the
+// timestamp. It lets a deployment substitute an approved keyed primitive for
+// SipHash-2-4. Mask must be deterministic, and its output must look random to
+// anyone without the key.
+type Masker interface {
+	Mask(input []byte) uint64
+}
+
+// Mask implements Masker with SipHash-2-4 under the key, the default used by
+// Encode and Decode
+func (k Key) Mask(input []byte) uint64 {
+	return siphash24(input, k.K0, k.K1)
+}
+
+// Mask implements Masker with SipHash-2-4 under the scheduled key
+func (ks *KeySchedule) Mask(input []byte) uint64 {
+	return ks.state.sum(input)
+}
+
+// maskerTimestampMask derives the 48-bit timestamp mask of u through m
+func maskerTimestampMask(u *UUID, m Masker) uint64 {
+	var sipmsg [10]byte
+	buildSipInputFromV7(u, &sipmsg)
+	return m.Mask(sipmsg[:]) & 0x0000FFFFFFFFFFFF
+}
+
+// EncodeMasker encodes a UUIDv7 as a UUIDv4 façade with the mask derived by m.
+// EncodeMasker(v7, key) equals Encode(v7, key).
+func EncodeMasker(v7 UUID, m Masker) UUID {
+	return applyMask(v7, maskerTimestampMask(&v7, m), 4)
+}
+
+// DecodeMasker decodes a façade made by EncodeMasker with the same Masker
+func DecodeMasker(v4facade UUID, m Masker) UUID {
+	return applyMask(v4facade, maskerTimestampMask(&v4facade, m), 7)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"testing"
+)
+
+// xorMasker is a stand-in primitive that folds the input into a constant
+type xorMasker uint64
+
+func (x xorMasker) Mask(input []byte) uint64 {
+	h := uint64(x)
+	for _, b := range input {
+		h = (h ^ uint64(b)) * 0x100000001b3
+	}
+	return h
+}
+
+func TestEncodeDecodeMasker(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	ks := NewKeySchedule(key)
+
+	for i := range uint64(64) {
+		v7 := NewV7Exact(1704067200000+i, uint16(i), i*0x9e3779b97f4a7c15)
+
+		// The SipHash implementations match Encode and Decode
+		want := Encode(v7, key)
+		if got := EncodeMasker(v7, key); got != want {
+			t.Errorf("EncodeMasker(%d, Key): got %v, want %v", i, got, want)
+		}
+		if got := EncodeMasker(v7, ks); got != want {
+			t.Errorf("EncodeMasker(%d, *KeySchedule): got %v, want %v", i, got, want)
+		}
+		if got := DecodeMasker(want, key); got != v7 {
+			t.Errorf("DecodeMasker(%d, Key): got %v, want %v", i, got, v7)
+		}
+
+		// A custom primitive round-trips and produces a different façade
+		m := xorMasker(0xcbf29ce484222325)
+		facade := EncodeMasker(v7, m)
+		if facade.Version() != 4 {
+			t.Errorf("EncodeMasker(%d, custom) version: got %d, want 4", i, facade.Version())
+		}
+		if facade == want {
+			t.Errorf("EncodeMasker(%d, custom) should differ from Encode", i)
+		}
+		if got := DecodeMasker(facade, m); got != v7 {
+			t.Errorf("DecodeMasker(%d, custom): got %v, want %v", i, got, v7)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParseBatch parses every string in ss. The returned slice has one entry per
+// input; entries that failed to parse are left as the zero UUID. All failures
+// are reported together as an errors.Join of per-entry errors naming the index
+// and offending string, and each wraps the underlying Parse error.
+func ParseBatch(ss []string) ([]UUID, error) {
+	out := make([]UUID, len(ss))
+	var errs []error
+	for i, s := range ss {
+		if err := ParseInto(s, &out[i]); err != nil {
+			errs = append(errs, fmt.Errorf("index %d (%q): %w", i, s, err))
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// ParseCase parses a UUID string in canonical format (8-4-4-4-12). When
+// allowUpper is false, any uppercase hex digit A-F is rejected with
+// ErrInvalidHex, enforcing the lowercase form RFC 9562 recommends for output.
+// With allowUpper true it behaves like Parse.
+func ParseCase(s string, allowUpper bool) (UUID, error) {
+	var out UUID
+	if err := decodeCanonical(s, &out, allowUpper); err != nil {
+		return UUID{}, err
+	}
+	return out, nil
+}
+
+// ParseVersion parses a UUID string like Parse and also returns its version
+// nibble, saving the usual follow-up Version call. The version is 0 on error.
+func ParseVersion(s string) (UUID, int, error) {
+	var out UUID
+	if err := ParseInto(s, &out); err != nil {
+		return UUID{}, 0, err
+	}
+	return out, out.Version(), nil
+}
+
+// ParseNonSpecial parses a UUID string like Parse but rejects the Nil and Max
+// UUIDs with ErrSpecialUUID, since at an API boundary they are placeholders
+// or probing attempts rather than real identifiers
+func ParseNonSpecial(s string) (UUID, error) {
+	var out UUID
+	if err := ParseInto(s, &out); err != nil {
+		return UUID{}, err
+	}
+	if out.IsZero() || out.IsMax() {
+		return UUID{}, ErrSpecialUUID
+	}
+	return out, nil
+}
+
+// ParsePaddedHex parses a bare hex number of up to 32 digits whose leading
+// zeros were stripped, e.g. a UUID stored as a trimmed integer. s is
+// left-padded with '0' to 32 digits and decoded big-endian; either hex case
+// is accepted. Empty or longer input returns ErrInvalidLength and non-hex
+// characters return ErrInvalidHex.
+func ParsePaddedHex(s string) (UUID, error) {
+	if len(s) == 0 || len(s) > 32 {
+		return UUID{}, ErrInvalidLength
+	}
+
+	var out UUID
+	pad := 32 - len(s)
+	for i := pad; i < 32; i++ {
+		v := hexval(s[i-pad])
+		if v < 0 {
+			return UUID{}, ErrInvalidHex
+		}
+		out[i/2] |= byte(v) << (4 * (1 - i%2))
+	}
+	return out, nil
+}
+
+// ParseAt parses the 36-character canonical UUID that starts at offset in s,
+// such as a fixed column of a log line. It returns ErrInvalidLength if the
+// UUID would extend past either end of s. The subslice shares s's memory, so
+// nothing is copied or allocated.
+func ParseAt(s string, offset int) (UUID, error) {
+	if offset < 0 || offset > len(s)-36 {
+		return UUID{}, ErrInvalidLength
+	}
+	var out UUID
+	if err := ParseInto(s[offset:offset+36], &out); err != nil {
+		return UUID{}, err
+	}
+	return out, nil
+}
+
+// ParseRawBytes parses a database column holding either the 16 raw bytes or
+// the 36-character canonical string. It takes []byte so a sql.RawBytes can be
+// passed directly. The result is a copy and nothing retains b, so it is safe
+// to call before the driver reuses the buffer on the next Scan. Other lengths
+// return ErrInvalidLength.
+func ParseRawBytes(b []byte) (UUID, error) {
+	switch len(b) {
+	case 16:
+		return UUID(b), nil
+	case 36:
+		return ParseBytes(b)
+	}
+	return UUID{}, ErrInvalidLength
+}
+
+// ParseExpectVersion parses a UUID string like Parse and returns
+// ErrInvalidVersion unless its version is want, e.g.
+// ParseExpectVersion(s, Version7) for an endpoint that takes UUIDv7s only
+func ParseExpectVersion(s string, want int) (UUID, error) {
+	var out UUID
+	if err := ParseInto(s, &out); err != nil {
+		return UUID{}, err
+	}
+	if out.Version() != want {
+		return UUID{}, ErrInvalidVersion
+	}
+	return out, nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseBatch(t *testing.T) {
+	good := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	want, _ := Parse(good)
+
+	ids, err := ParseBatch([]string{good, good})
+	if err != nil {
+		t.Fatalf("ParseBatch() error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != want || ids[1] != want {
+		t.Errorf("ParseBatch() mismatch: got %v", ids)
+	}
+
+	ss := []string{
+		good,
+		"too-short",
+		good,
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5ezz",
+	}
+	ids, err = ParseBatch(ss)
+	if err == nil {
+		t.Fatal("ParseBatch() should report errors")
+	}
+	if len(ids) != len(ss) {
+		t.Fatalf("ParseBatch() length: got %d, want %d", len(ids), len(ss))
+	}
+	if ids[0] != want || ids[2] != want {
+		t.Error("ParseBatch() should populate successful entries")
+	}
+	if !ids[1].IsZero() || !ids[3].IsZero() {
+		t.Error("ParseBatch() should leave failed entries zero")
+	}
+
+	if !errors.Is(err, ErrInvalidLength) || !errors.Is(err, ErrInvalidHex) {
+		t.Errorf("ParseBatch() error should wrap every failure: %v", err)
+	}
+	msg := err.Error()
+	for _, part := range []string{"index 1", `"too-short"`, "index 3", ss[3]} {
+		if !strings.Contains(msg, part) {
+			t.Errorf("ParseBatch() error %q should contain %q", msg, part)
+		}
+	}
+	if strings.Contains(msg, "index 0") || strings.Contains(msg, "index 2") {
+		t.Errorf("ParseBatch() error %q should not mention valid entries", msg)
+	}
+}
+
+func TestParseCase(t *testing.T) {
+	lower := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	upper := "018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F"
+	mixed := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6F"
+	want, _ := Parse(lower)
+
+	tests := []struct {
+		in         string
+		allowUpper bool
+		wantErr    error
+	}{
+		{lower, false, nil},
+		{lower, true, nil},
+		{upper, true, nil},
+		{mixed, true, nil},
+		{upper, false, ErrInvalidHex},
+		{mixed, false, ErrInvalidHex},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g", false, ErrInvalidHex},
+		{"too-short", false, ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		got, err := ParseCase(tt.in, tt.allowUpper)
+		if err != tt.wantErr {
+			t.Errorf("ParseCase(%q, %v): got error %v, want %v", tt.in, tt.allowUpper, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != want {
+			t.Errorf("ParseCase(%q, %v): got %v, want %v", tt.in, tt.allowUpper, got, want)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantVer int
+		wantErr error
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", 7, nil},
+		{"2463c780-7fca-4def-8c3f-7b1a2c4d5e6f", 4, nil},
+		{"00000000-0000-0000-0000-000000000000", 0, nil},
+		{"018f2d9f-9a2a-7def-8c3f", 0, ErrInvalidLength},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g", 0, ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		u, ver, err := ParseVersion(tt.in)
+		if err != tt.wantErr {
+			t.Errorf("ParseVersion(%q): got error %v, want %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if ver != tt.wantVer {
+			t.Errorf("ParseVersion(%q): got version %d, want %d", tt.in, ver, tt.wantVer)
+		}
+		if err == nil {
+			if want, _ := Parse(tt.in); u != want {
+				t.Errorf("ParseVersion(%q): got %v, want %v", tt.in, u, want)
+			}
+		} else if !u.IsZero() {
+			t.Errorf("ParseVersion(%q): got %v on error, want zero UUID", tt.in, u)
+		}
+	}
+}
+
+func TestParseNonSpecial(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr error
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", nil},
+		{"00000000-0000-0000-0000-000000000001", nil},
+		{"00000000-0000-0000-0000-000000000000", ErrSpecialUUID},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", ErrSpecialUUID},
+		{"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", ErrSpecialUUID},
+		{"not-a-uuid", ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		u, err := ParseNonSpecial(tt.in)
+		if err != tt.wantErr {
+			t.Errorf("ParseNonSpecial(%q): got error %v, want %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err != nil && !u.IsZero() {
+			t.Errorf("ParseNonSpecial(%q): got %v on error, want zero UUID", tt.in, u)
+		}
+	}
+}
+
+func TestParsePaddedHex(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{"018f2d9f9a2a7def8c3f7b1a2c4d5e6f", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", nil},
+		{"18f2d9f9a2a7def8c3f7b1a2c4d5e6f", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", nil},
+		{"ABC", "00000000-0000-0000-0000-000000000abc", nil},
+		{"1", "00000000-0000-0000-0000-000000000001", nil},
+		{"", "", ErrInvalidLength},
+		{"0018f2d9f9a2a7def8c3f7b1a2c4d5e6f", "", ErrInvalidLength},
+		{"12g4", "", ErrInvalidHex},
+		{"018f2d9f-9a2a", "", ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		got, err := ParsePaddedHex(tt.in)
+		if err != tt.wantErr {
+			t.Errorf("ParsePaddedHex(%q): got error %v, want %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			if !got.IsZero() {
+				t.Errorf("ParsePaddedHex(%q): got %v on error, want zero UUID", tt.in, got)
+			}
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParsePaddedHex(%q): got %v, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAt(t *testing.T) {
+	line := "2024-05-01T12:00:00Z INFO 018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f done"
+	want, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		name    string
+		s       string
+		offset  int
+		wantErr error
+	}{
+		{"column", line, 26, nil},
+		{"whole", line[26:62], 0, nil},
+		{"at-end", line[:62], 26, nil},
+		{"misaligned", line, 25, ErrInvalidFormat},
+		{"past-end", line, len(line) - 35, ErrInvalidLength},
+		{"negative", line, -1, ErrInvalidLength},
+		{"short", "abc", 0, ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		got, err := ParseAt(tt.s, tt.offset)
+		if err != tt.wantErr {
+			t.Errorf("ParseAt(%s): got error %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != want {
+			t.Errorf("ParseAt(%s): got %v, want %v", tt.name, got, want)
+		}
+		if err != nil && !got.IsZero() {
+			t.Errorf("ParseAt(%s): got %v on error, want zero UUID", tt.name, got)
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _, _ = ParseAt(line, 26) }); n != 0 {
+		t.Errorf("ParseAt() allocations: got %v, want 0", n)
+	}
+}
+
+func TestParseRawBytes(t *testing.T) {
+	want, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	// One driver-owned buffer reused for every row, like sql.RawBytes
+	buf := make([]byte, 0, 64)
+	rows := [][]byte{want[:], []byte(want.String()), []byte(strings.ToUpper(want.String()))}
+	var got []UUID
+	for _, row := range rows {
+		buf = append(buf[:0], row...)
+		u, err := ParseRawBytes(buf)
+		if err != nil {
+			t.Fatalf("ParseRawBytes(%q) error: %v", row, err)
+		}
+		got = append(got, u)
+	}
+	// Clobber the buffer as the next Scan would
+	buf = buf[:cap(buf)]
+	for i := range buf {
+		buf[i] = 0xAA
+	}
+	for i, u := range got {
+		if u != want {
+			t.Errorf("ParseRawBytes(row %d) after buffer reuse: got %v, want %v", i, u, want)
+		}
+	}
+
+	tests := []struct {
+		in      []byte
+		wantErr error
+	}{
+		{nil, ErrInvalidLength},
+		{make([]byte, 15), ErrInvalidLength},
+		{make([]byte, 32), ErrInvalidLength},
+		{[]byte("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g"), ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		u, err := ParseRawBytes(tt.in)
+		if err != tt.wantErr || !u.IsZero() {
+			t.Errorf("ParseRawBytes(%q): got %v, %v, want zero UUID, %v", tt.in, u, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseExpectVersion(t *testing.T) {
+	v7 := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	v4 := "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f"
+
+	tests := []struct {
+		in      string
+		want    int
+		wantErr error
+	}{
+		{v7, Version7, nil},
+		{v4, Version4, nil},
+		{v7, Version4, ErrInvalidVersion},
+		{v4, Version7, ErrInvalidVersion},
+		{"00000000-0000-0000-0000-000000000000", Version7, ErrInvalidVersion},
+		{"bad", Version7, ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		u, err := ParseExpectVersion(tt.in, tt.want)
+		if err != tt.wantErr {
+			t.Errorf("ParseExpectVersion(%q, %d): got error %v, want %v", tt.in, tt.want, err, tt.wantErr)
+			continue
+		}
+		if err == nil && u.Version() != tt.want {
+			t.Errorf("ParseExpectVersion(%q, %d): got version %d", tt.in, tt.want, u.Version())
+		}
+		if err != nil && !u.IsZero() {
+			t.Errorf("ParseExpectVersion(%q, %d): got %v on error, want zero UUID", tt.in, tt.want, u)
+		}
+	}
+}
+
+// TestParseEntryPointsLockstep feeds the same inputs through every
+// canonical-format entry point to prove they share one decoder
+func TestParseEntryPointsLockstep(t *testing.T) {
+	inputs := []string{
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F",
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6",
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f0",
+		"018f2d9f9a2a-7def-8c3f-7b1a2c4d5e6f0",
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g",
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e\x80f",
+		"",
+	}
+	entries := []struct {
+		name  string
+		parse func(string) (UUID, error)
+	}{
+		{"ParseInto", func(s string) (UUID, error) {
+			var u UUID
+			if err := ParseInto(s, &u); err != nil {
+				return UUID{}, err
+			}
+			return u, nil
+		}},
+		{"ParseBytes", func(s string) (UUID, error) { return ParseBytes([]byte(s)) }},
+		{"ParseCase", func(s string) (UUID, error) { return ParseCase(s, true) }},
+		{"ParseAt", func(s string) (UUID, error) { return ParseAt("> "+s, 2) }},
+		{"UnmarshalText", func(s string) (UUID, error) {
+			var u UUID
+			err := u.UnmarshalText([]byte(s))
+			return u, err
+		}},
+	}
+	for _, s := range inputs {
+		want, wantErr := Parse(s)
+		for _, e := range entries {
+			got, err := e.parse(s)
+			if e.name == "ParseAt" && len(s) != 36 {
+				// ParseAt reads exactly 36 bytes, so only its bounds check differs
+				continue
+			}
+			if got != want || err != wantErr {
+				t.Errorf("%s(%q): got %v, %v, want %v, %v as Parse", e.name, s, got, err, want, wantErr)
+			}
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _, _ = Parse(inputs[0]) }); n != 0 {
+		t.Errorf("Parse() allocations: got %v, want 0", n)
+	}
+	b := []byte(inputs[0])
+	if n := testing.AllocsPerRun(100, func() { _, _ = ParseBytes(b) }); n != 0 {
+		t.Errorf("ParseBytes() allocations: got %v, want 0", n)
+	}
+}
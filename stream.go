@@ -0,0 +1,139 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"io"
+)
+
+// DecodeChan decodes façades from in and sends the UUIDv7 values, in order,
+// on the returned channel. It launches one goroutine, which closes the output
+// channel and exits once in is closed and every value has been delivered.
+// The goroutine blocks while the output is not being read, so to avoid
+// leaking it, the consumer must keep reading until the output is closed; a
+// consumer that stops early should have the producer close in and then drain
+// the output.
+func DecodeChan(in <-chan UUID, key Key) <-chan UUID {
+	out := make(chan UUID)
+	go func() {
+		defer close(out)
+		for facade := range in {
+			out <- Decode(facade, key)
+		}
+	}()
+	return out
+}
+
+// EncodingWriter rewrites canonical UUIDv7 strings into their façades as
+// bytes pass through to an underlying writer. Create one with
+// NewEncodingWriter.
+type EncodingWriter struct {
+	w       io.Writer
+	key     Key
+	pending []byte // bytes not yet written; may hold a partial UUID
+	ready   int    // leading pending bytes already scanned and final
+	prev    byte   // last byte written, to check the left boundary
+}
+
+// NewEncodingWriter returns a writer that scans the stream for canonical
+// 36-character UUID strings and replaces each UUIDv7 with its lowercase
+// façade under key before passing the bytes to w. Only complete strings not
+// adjoined by another hex digit or dash are rewritten; other UUID versions
+// and malformed candidates pass through unchanged. A candidate split across
+// Write calls is held back until it can be decided, so call Flush once the
+// stream ends.
+func NewEncodingWriter(w io.Writer, key Key) *EncodingWriter {
+	return &EncodingWriter{w: w, key: key}
+}
+
+// Write rewrites UUIDv7 strings in p and writes what can be decided to the
+// underlying writer. It always reports len(p), as p is buffered even when w
+// fails: held-back and unwritten bytes are written on a later Write or Flush,
+// so p must not be written again after an error.
+func (e *EncodingWriter) Write(p []byte) (int, error) {
+	e.pending = append(e.pending, p...)
+	e.ready = e.scan(e.ready, false)
+	return len(p), e.emit()
+}
+
+// Flush decides and writes any held-back bytes, treating the end of the
+// stream as a boundary. It does not flush w itself.
+func (e *EncodingWriter) Flush() error {
+	e.ready = e.scan(e.ready, true)
+	return e.emit()
+}
+
+// emit writes the ready pending bytes and keeps whatever w did not accept
+func (e *EncodingWriter) emit() error {
+	if e.ready == 0 {
+		return nil
+	}
+	n, err := e.w.Write(e.pending[:e.ready])
+	if err == nil && n < e.ready {
+		err = io.ErrShortWrite
+	}
+	if n > 0 {
+		e.prev = e.pending[n-1]
+		e.pending = append(e.pending[:0], e.pending[n:]...)
+		e.ready -= n
+	}
+	return err
+}
+
+// scan rewrites UUIDv7 strings in pending from index from and returns how
+// many leading bytes are final. Unless final is set, it stops at a candidate
+// that needs more input: a UUID-shaped prefix, or a full string whose right
+// boundary is unseen.
+func (e *EncodingWriter) scan(from int, final bool) int {
+	buf := e.pending
+	prev := e.prev
+	for i := from; i < len(buf); i++ {
+		if i > 0 {
+			prev = buf[i-1]
+		}
+		if hexval(buf[i]) < 0 || isUUIDByte(prev) {
+			continue
+		}
+
+		rest := buf[i:]
+		if len(rest) < 36 || (len(rest) == 36 && !final) {
+			if !final && uuidShapedPrefix(rest) {
+				return i
+			}
+			continue
+		}
+		if len(rest) > 36 && isUUIDByte(rest[36]) {
+			continue
+		}
+
+		var v7 UUID
+		if decodeCanonical(rest[:36], &v7, true) != nil || v7.Version() != 7 {
+			continue
+		}
+		facade := Encode(v7, e.key)
+		facade.formatInto((*[36]byte)(rest[:36]), hexLower)
+		i += 35
+	}
+	return len(buf)
+}
+
+// isUUIDByte reports whether c can appear in a canonical UUID string
+func isUUIDByte(c byte) bool {
+	return c == '-' || hexval(c) >= 0
+}
+
+// uuidShapedPrefix reports whether b could begin a canonical UUID string
+func uuidShapedPrefix(b []byte) bool {
+	for i, c := range b {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if c != '-' {
+				return false
+			}
+		} else if hexval(c) < 0 {
+			return false
+		}
+	}
+	return true
+}
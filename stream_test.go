@@ -0,0 +1,152 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeChan(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	want := make([]UUID, 100)
+	for i := range want {
+		want[i] = NewV7Exact(uint64(1704067200000+i), uint16(i), uint64(i)*0x9e3779b97f4a7c15)
+	}
+
+	in := make(chan UUID)
+	go func() {
+		defer close(in)
+		for _, u7 := range want {
+			in <- Encode(u7, key)
+		}
+	}()
+
+	i := 0
+	for got := range DecodeChan(in, key) {
+		if i >= len(want) {
+			t.Fatalf("DecodeChan() produced more than %d values", len(want))
+		}
+		if got != want[i] {
+			t.Errorf("DecodeChan()[%d]: got %v, want %v", i, got, want[i])
+		}
+		i++
+	}
+	if i != len(want) {
+		t.Errorf("DecodeChan() produced %d values, want %d", i, len(want))
+	}
+
+	// A closed input closes the output
+	empty := make(chan UUID)
+	close(empty)
+	if _, ok := <-DecodeChan(empty, key); ok {
+		t.Error("DecodeChan() output should be closed when the input is closed")
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+// flakyWriter accepts at most max bytes per call into buf and fails every
+// other call, after a partial write
+type flakyWriter struct {
+	buf   bytes.Buffer
+	max   int
+	calls int
+}
+
+func (f *flakyWriter) Write(p []byte) (int, error) {
+	f.calls++
+	n, _ := f.buf.Write(p[:min(len(p), f.max)])
+	if f.calls%2 == 0 {
+		return n, errors.New("write failed")
+	}
+	return n, nil
+}
+
+func TestEncodingWriter(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7 := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	facade := "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f"
+	v4 := "7bbcb40d-5506-42d0-9e7f-e413d00cc9fd"
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"json", `{"id":"` + v7 + `","ref":"` + v4 + `"}`, `{"id":"` + facade + `","ref":"` + v4 + `"}`},
+		{"bare", v7, facade},
+		{"repeated", v7 + " " + v7, facade + " " + facade},
+		{"uppercase", strings.ToUpper(v7), facade},
+		{"hex-before", "a" + v7, "a" + v7},
+		{"hex-after", v7 + "0", v7 + "0"},
+		{"dash-after", v7 + "-", v7 + "-"},
+		{"truncated", v7[:30], v7[:30]},
+		{"plain", "no identifiers here", "no identifiers here"},
+	}
+	for _, tt := range tests {
+		// One Write call, then byte by byte to split every candidate
+		for _, chunk := range []int{len(tt.in), 1} {
+			var out bytes.Buffer
+			w := NewEncodingWriter(&out, key)
+			for i := 0; i < len(tt.in); i += chunk {
+				p := []byte(tt.in[i:min(i+chunk, len(tt.in))])
+				if n, err := w.Write(p); n != len(p) || err != nil {
+					t.Fatalf("EncodingWriter(%s).Write(): got %d, %v", tt.name, n, err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("EncodingWriter(%s).Flush(): %v", tt.name, err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("EncodingWriter(%s, chunk %d): got %s, want %s", tt.name, chunk, got, tt.want)
+			}
+		}
+	}
+
+	// A complete candidate is held back until its right boundary is known
+	var out bytes.Buffer
+	w := NewEncodingWriter(&out, key)
+	w.Write([]byte("id=" + v7))
+	if got := out.String(); got != "id=" {
+		t.Errorf("EncodingWriter before Flush: got %q, want %q", got, "id=")
+	}
+	w.Flush()
+	if got := out.String(); got != "id="+facade {
+		t.Errorf("EncodingWriter after Flush: got %q, want %q", got, "id="+facade)
+	}
+
+	if n, err := NewEncodingWriter(errWriter{}, key).Write([]byte("x")); n != 1 || err == nil {
+		t.Errorf("EncodingWriter.Write(errWriter): got %d, %v, want 1 and the underlying error", n, err)
+	}
+
+	// Failed and short writes lose and repeat nothing: unwritten bytes are
+	// kept and retried by later calls
+	in := `{"id":"` + v7 + `","ref":"` + v4 + `"}`
+	want := `{"id":"` + facade + `","ref":"` + v4 + `"}`
+	fw := &flakyWriter{max: 5}
+	w = NewEncodingWriter(fw, key)
+	for i := 0; i < len(in); i += 7 {
+		p := []byte(in[i:min(i+7, len(in))])
+		if n, _ := w.Write(p); n != len(p) {
+			t.Fatalf("EncodingWriter(flaky).Write(): got %d, want %d", n, len(p))
+		}
+	}
+	for range 100 {
+		if w.Flush() == nil && len(w.pending) == 0 {
+			break
+		}
+	}
+	if got := fw.buf.String(); got != want {
+		t.Errorf("EncodingWriter(flaky): got %s, want %s", got, want)
+	}
+}
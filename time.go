@@ -0,0 +1,299 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"math/bits"
+	"time"
+)
+
+// msPerHour is the number of milliseconds in one hour
+const msPerHour = 60 * 60 * 1000
+
+// decodeTimestamp returns the Unix millisecond timestamp hidden in a façade
+func decodeTimestamp(facade *UUID, key Key) uint64 {
+	return rd48be(facade[0:6]) ^ timestampMask(facade, key)
+}
+
+// msToTime converts a Unix millisecond timestamp to a UTC time.Time
+func msToTime(ms uint64) time.Time {
+	return time.UnixMilli(int64(ms)).UTC()
+}
+
+// DecodeWithExpiry decodes a façade and checks that its UUIDv7 is no older
+// than ttl at now. It returns ErrExpired if now minus the decoded creation
+// time exceeds ttl. This gives stateless expiry for short-lived handles.
+func DecodeWithExpiry(facade UUID, key Key, ttl time.Duration, now time.Time) (UUID, error) {
+	v7 := Decode(facade, key)
+	if now.Sub(msToTime(rd48be(v7[0:6]))) > ttl {
+		return UUID{}, ErrExpired
+	}
+	return v7, nil
+}
+
+// NewExpiringFacade mints a façade for a single-use link that
+// ValidateExpiringFacade accepts until ttl has passed. The expiry is the
+// creation time, now, plus ttl; ttl itself is not embedded, so the validator
+// must use the same ttl. A non-positive ttl returns ErrExpired.
+func NewExpiringFacade(key Key, ttl time.Duration) (UUID, error) {
+	if ttl <= 0 {
+		return UUID{}, ErrExpired
+	}
+	return NewFacade(key)
+}
+
+// ValidateExpiringFacade returns ErrExpired if the façade's creation time plus
+// ttl is before now, and nil otherwise. It is DecodeWithExpiry without the
+// decoded UUIDv7.
+func ValidateExpiringFacade(facade UUID, key Key, ttl time.Duration, now time.Time) error {
+	_, err := DecodeWithExpiry(facade, key, ttl, now)
+	return err
+}
+
+// FacadeAge returns how long ago the façade's UUIDv7 was created, as of now.
+// A creation time slightly ahead of now (clock skew) yields zero rather than
+// a negative age.
+func FacadeAge(facade UUID, key Key, now time.Time) time.Duration {
+	age := now.Sub(msToTime(decodeTimestamp(&facade, key)))
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// FacadeIsRecent reports whether the façade's UUIDv7 was created within
+// [now-window, now], both ends inclusive. A creation time after now is not
+// recent; unlike FacadeAge, no allowance is made for clock skew.
+func FacadeIsRecent(facade UUID, key Key, window time.Duration, now time.Time) bool {
+	created := msToTime(decodeTimestamp(&facade, key))
+	return !created.After(now) && !created.Before(now.Add(-window))
+}
+
+// SuspectDoubleEncoded is a heuristic for a façade that was passed through
+// Encode twice. The mask depends only on the random bits, which Encode leaves
+// alone, so a second Encode cancels the first: the doubly encoded value
+// carries the plain timestamp, and decoding it once yields a masked, random
+// looking one. It reports true when the decoded timestamp is later than
+// now+maxSkew while the façade's raw bytes 0-5 read as a time no later than
+// that. A genuine façade trips this only when its masked timestamp happens to
+// look plausible, so treat a true result as a reason for review, not proof.
+func SuspectDoubleEncoded(facade UUID, key Key, maxSkew time.Duration) bool {
+	limit := timeToMs(timeNow().Add(maxSkew))
+	return decodeTimestamp(&facade, key) > limit && rd48be(facade[0:6]) <= limit
+}
+
+// FacadeDateKey decodes the façade's creation time and formats it in UTC with
+// layout, e.g. "20060102" for a yyyymmdd partition suffix
+func FacadeDateKey(facade UUID, key Key, layout string) string {
+	return msToTime(decodeTimestamp(&facade, key)).Format(layout)
+}
+
+// AsV7Time is a raw interpretation of bytes 0-5 as a 48-bit Unix millisecond
+// timestamp, regardless of the version nibble. For a UUIDv7 this is its
+// creation time; for a façade it is the masked value, useful only to study
+// the distribution of masked timestamps. The result is in UTC.
+func (u *UUID) AsV7Time() time.Time {
+	return msToTime(rd48be(u[0:6]))
+}
+
+// TimeIn returns the UUIDv7 creation time, as AsV7Time, in loc. Like
+// time.Time.In it panics if loc is nil.
+func (u *UUID) TimeIn(loc *time.Location) time.Time {
+	return u.AsV7Time().In(loc)
+}
+
+// Decompose returns the UUIDv7 timestamp as a UTC time.Time along with the
+// 12-bit rand_a and 62-bit rand_b fields, the time.Time counterpart to
+// Fields. NewV7Exact(uint64(t.UnixMilli()), randA, randB) rebuilds a v7.
+func (u *UUID) Decompose() (t time.Time, randA uint16, randB uint64) {
+	return u.AsV7Time(), randA12(u), randB62(u)
+}
+
+// TimePrecision returns the resolution of the timestamp embedded for the
+// UUID's version: 100ns for versions 1 and 6, about 7m10s for version 2
+// (whose low 32 time bits hold a local ID), 1ms for version 7, and 0 for
+// versions without a timestamp. A converter can compare precisions to spot
+// conversions that drop or fabricate sub-millisecond detail.
+func (u *UUID) TimePrecision() time.Duration {
+	switch u.Version() {
+	case 1, 6:
+		return 100 * time.Nanosecond
+	case 2:
+		return (1 << 32) * 100 * time.Nanosecond
+	case Version7:
+		return time.Millisecond
+	}
+	return 0
+}
+
+// gregorianOffset is the number of 100ns ticks from 1582-10-15, the UUIDv1 and
+// UUIDv6 epoch, to the Unix epoch
+const gregorianOffset = 0x01B21DD213814000
+
+// ToV6 converts a UUIDv7 to a UUIDv6 for consumers that only understand the
+// reordered v1 time layout. The Unix millisecond timestamp becomes a 60-bit
+// count of 100ns ticks since 1582-10-15, stored most significant bits first
+// with version 6. The variant and rand_b carry over as the clock sequence and
+// node. rand_a is dropped, so UUIDs from the same millisecond share a v6
+// timestamp and their order within it is lost. Timestamps after the year 5236
+// wrap the 60-bit field. It returns the zero UUID if u is not a UUIDv7.
+func (u *UUID) ToV6() UUID {
+	if u.Version() != 7 {
+		return UUID{}
+	}
+	ticks := (rd48be(u[0:6])*10000 + gregorianOffset) & (1<<60 - 1)
+
+	out := *u
+	wr48be(out[0:6], ticks>>12)
+	out[6] = byte(ticks >> 8 & 0x0F)
+	out[7] = byte(ticks)
+	out.setVersion(6)
+	return out
+}
+
+// timeToMs converts t to Unix milliseconds clamped to the 48-bit timestamp range
+func timeToMs(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	switch {
+	case ms < 0:
+		return 0
+	case ms > 0x0000FFFFFFFFFFFF:
+		return 0x0000FFFFFFFFFFFF
+	}
+	return uint64(ms)
+}
+
+// TimeUnit interprets the 48-bit timestamp field as a count of unit since the
+// Unix epoch, for v7-like schemes that store e.g. seconds rather than
+// milliseconds. TimeUnit(time.Millisecond) equals AsV7Time. unit must be
+// positive and no longer than time.Hour; otherwise the zero time.Time is
+// returned. The result is in UTC.
+func (u *UUID) TimeUnit(unit time.Duration) time.Time {
+	if unit <= 0 || unit > time.Hour {
+		return time.Time{}
+	}
+	n := rd48be(u[0:6])
+
+	// n*unit overflows 64 bits, so split unit into seconds and nanoseconds
+	secs := n * uint64(unit/time.Second)
+	hi, lo := bits.Mul64(n, uint64(unit%time.Second))
+	fracSecs, nsec := bits.Div64(hi, lo, uint64(time.Second))
+	return time.Unix(int64(secs+fracSecs), int64(nsec)).UTC()
+}
+
+// ClampTime rewrites the UUIDv7 timestamp (bytes 0-5) to the nearest bound if
+// it falls outside [lo, hi], preserving the version, variant and random
+// bits. Bounds outside the 48-bit millisecond range are clamped to it.
+// lo must not be after hi.
+func (u *UUID) ClampTime(lo, hi time.Time) {
+	ts := rd48be(u[0:6])
+	if loMs := timeToMs(lo); ts < loMs {
+		wr48be(u[0:6], loMs)
+	} else if hiMs := timeToMs(hi); ts > hiMs {
+		wr48be(u[0:6], hiMs)
+	}
+}
+
+// Before reports whether the UUIDv7 was generated before other. It compares
+// the 48-bit timestamps and breaks ties with rand_a, which counter-based
+// generators use as a sequence. For IDs that arrived in order, a false result
+// indicates the generator clock regressed.
+func (u *UUID) Before(other UUID) bool {
+	ts, ots := rd48be(u[0:6]), rd48be(other[0:6])
+	if ts != ots {
+		return ts < ots
+	}
+	return randA12(u) < randA12(&other)
+}
+
+// SortKey returns a byte-ordered key for a UUIDv7. A v7 is already laid out
+// timestamp-first, so the key is the UUID bytes as-is and byte order matches
+// creation order. For a façade the bytes are masked and sorting them is
+// meaningless; use DecodeSortKey instead.
+func (u *UUID) SortKey() [16]byte {
+	return [16]byte(*u)
+}
+
+// DecodeSortKey decodes a façade and returns the UUIDv7 bytes as a
+// time-ordered key, for stores that only support byte-ordered keys.
+func DecodeSortKey(facade UUID, key Key) [16]byte {
+	v7 := Decode(facade, key)
+	return v7.SortKey()
+}
+
+// OrderToken returns the 48-bit Unix millisecond creation time of u whether
+// it is a UUIDv7 or a v4 façade under key, so mixed collections, e.g. during
+// a migration, sort on one key. A version 4 UUID is decoded as a façade; any
+// other version is read directly, as AsV7Time does.
+func OrderToken(u UUID, key Key) uint64 {
+	if u.Version() == Version4 {
+		return decodeTimestamp(&u, key)
+	}
+	return rd48be(u[0:6])
+}
+
+// ExportToken returns an anonymized value for a UUIDv7 that orders by
+// creation time only to the precision of bucket. The high bits hold the
+// bucket index, the timestamp divided by bucket; the remaining low bits hold
+// a SipHash-2-4 of the whole v7 and bucket under key. Tokens from different
+// buckets therefore sort by bucket, while tokens within one bucket are
+// shuffled, hiding the exact timestamp and the order inside the bucket.
+//
+// What a partner still learns: the bucket each ID falls in, so a coarser
+// bucket leaks less, and how many IDs share a bucket. The token is stable for
+// the same v7, key and bucket, so exports made with one key can be joined;
+// use a separate key per partner to prevent that. Compare tokens only when
+// made with the same bucket, since the split between the two parts depends on
+// it. Distinct IDs in one bucket collide with negligible probability: an
+// hourly bucket leaves 37 hash bits.
+//
+// bucket must be a positive whole number of milliseconds, else it returns
+// ErrInvalidBucket; a v7 of another version returns ErrInvalidVersion.
+func ExportToken(v7 UUID, key Key, bucket time.Duration) (uint64, error) {
+	if bucket < time.Millisecond || bucket%time.Millisecond != 0 {
+		return 0, ErrInvalidBucket
+	}
+	if v7.Version() != Version7 {
+		return 0, ErrInvalidVersion
+	}
+	bucketMs := uint64(bucket / time.Millisecond)
+	lowBits := 64 - bits.Len64(0x0000FFFFFFFFFFFF/bucketMs)
+
+	var msg [24]byte
+	copy(msg[:], v7[:])
+	for i := range 8 {
+		msg[16+i] = byte(bucketMs >> (8 * i))
+	}
+	h := siphash24(msg[:], key.K0, key.K1)
+
+	idx := rd48be(v7[0:6]) / bucketMs
+	return idx<<lowBits | h>>(64-lowBits), nil
+}
+
+// MonotonicKey packs the UUIDv7 timestamp and rand_a counter into a 60-bit
+// integer, (ts48 << 12) | rand_a. It orders counter-based v7s the same way
+// Before does and is cheaper to index than the full 16 bytes.
+func (u *UUID) MonotonicKey() uint64 {
+	return rd48be(u[0:6])<<12 | uint64(randA12(u))
+}
+
+// TimestampAfter reports whether the UUIDv7 timestamp is strictly later than
+// a stored Unix millisecond watermark, using a single integer comparison
+func (u *UUID) TimestampAfter(watermarkMs uint64) bool {
+	return rd48be(u[0:6]) > watermarkMs
+}
+
+// GroupByHour buckets façades by the hour their UUIDv7 was created.
+// Each façade is decoded exactly once and the map key is the Unix hour index
+// (Unix milliseconds / 3600000). The groups hold the original façades, in
+// input order, not the decoded UUIDv7 values.
+func GroupByHour(facades []UUID, key Key) map[int64][]UUID {
+	groups := make(map[int64][]UUID)
+	for i := range facades {
+		hour := int64(decodeTimestamp(&facades[i], key) / msPerHour)
+		groups[hour] = append(groups[hour], facades[i])
+	}
+	return groups
+}
@@ -0,0 +1,594 @@
+// Copyright 2025 CastleBytes https://castlebytes.com
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid47
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestGroupByHour(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	base := uint64(1704067200000) // 2024-01-01T00:00:00Z, hour 473352
+	stamps := []uint64{
+		base,
+		base + msPerHour - 1,
+		base + msPerHour,
+		base + 2*msPerHour + 42,
+		base + 30*60*1000,
+	}
+
+	facades := make([]UUID, len(stamps))
+	for i, ts := range stamps {
+		facades[i] = Encode(NewV7Exact(ts, uint16(i), uint64(i)*0x9e3779b97f4a7c15), key)
+	}
+
+	groups := GroupByHour(facades, key)
+	if len(groups) != 3 {
+		t.Fatalf("GroupByHour: got %d groups, want 3", len(groups))
+	}
+
+	hour := int64(base / msPerHour)
+	want := map[int64][]UUID{
+		hour:     {facades[0], facades[1], facades[4]},
+		hour + 1: {facades[2]},
+		hour + 2: {facades[3]},
+	}
+	for h, ids := range want {
+		got := groups[h]
+		if len(got) != len(ids) {
+			t.Errorf("GroupByHour hour %d: got %d façades, want %d", h, len(got), len(ids))
+			continue
+		}
+		for i := range ids {
+			if got[i] != ids[i] {
+				t.Errorf("GroupByHour hour %d[%d]: got %v, want %v", h, i, got[i], ids[i])
+			}
+		}
+	}
+
+	if groups := GroupByHour(nil, key); len(groups) != 0 {
+		t.Errorf("GroupByHour(nil): got %d groups, want 0", len(groups))
+	}
+}
+
+func TestDecodeWithExpiry(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	u7 := NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF)
+	facade := Encode(u7, key)
+	ttl := 5 * time.Minute
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantErr error
+	}{
+		{"fresh", created.Add(time.Minute), nil},
+		{"at-ttl", created.Add(ttl), nil},
+		{"expired", created.Add(ttl + time.Millisecond), ErrExpired},
+		{"future", created.Add(-time.Minute), nil},
+	}
+	for _, tt := range tests {
+		got, err := DecodeWithExpiry(facade, key, ttl, tt.now)
+		if err != tt.wantErr {
+			t.Errorf("DecodeWithExpiry(%s): got error %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != u7 {
+			t.Errorf("DecodeWithExpiry(%s): got %v, want %v", tt.name, got, u7)
+		}
+		if err != nil && !got.IsZero() {
+			t.Errorf("DecodeWithExpiry(%s): got %v on error, want zero UUID", tt.name, got)
+		}
+	}
+}
+
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b UUID
+		want bool
+	}{
+		{"earlier", NewV7Exact(1000, 0x0FFF, 1), NewV7Exact(1001, 0, 0), true},
+		{"later", NewV7Exact(1001, 0, 0), NewV7Exact(1000, 0x0FFF, 1), false},
+		{"counter", NewV7Exact(1000, 1, 9), NewV7Exact(1000, 2, 0), true},
+		{"counter-regressed", NewV7Exact(1000, 2, 0), NewV7Exact(1000, 1, 9), false},
+		{"equal", NewV7Exact(1000, 1, 0), NewV7Exact(1000, 1, 5), false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Before(tt.b); got != tt.want {
+			t.Errorf("Before(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	ids := []UUID{
+		NewV7Exact(1000, 0x0FFF, 0x3FFFFFFFFFFFFFFF),
+		NewV7Exact(1001, 0, 0),
+		NewV7Exact(1001, 1, 0),
+		NewV7Exact(1<<40, 0, 7),
+	}
+	for i := range ids[1:] {
+		a, b := ids[i].SortKey(), ids[i+1].SortKey()
+		if bytes.Compare(a[:], b[:]) >= 0 {
+			t.Errorf("SortKey(%d) should order before SortKey(%d)", i, i+1)
+		}
+
+		fa, fb := Encode(ids[i], key), Encode(ids[i+1], key)
+		da, db := DecodeSortKey(fa, key), DecodeSortKey(fb, key)
+		if da != a || db != b {
+			t.Errorf("DecodeSortKey(%d) should match the UUIDv7 SortKey", i)
+		}
+	}
+}
+
+func TestFacadeAge(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	facade := Encode(NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF), key)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Duration
+	}{
+		{"same", created, 0},
+		{"later", created.Add(90 * time.Minute), 90 * time.Minute},
+		{"skewed", created.Add(-2 * time.Second), 0},
+	}
+	for _, tt := range tests {
+		if got := FacadeAge(facade, key, tt.now); got != tt.want {
+			t.Errorf("FacadeAge(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAsV7Time(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	u7 := NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF)
+
+	if got := u7.AsV7Time(); !got.Equal(created) || got.Location() != time.UTC {
+		t.Errorf("AsV7Time(): got %v, want %v", got, created)
+	}
+
+	// A façade is read the same way, yielding the masked timestamp
+	facade := Encode(u7, key)
+	want := time.UnixMilli(int64(rd48be(facade[0:6]))).UTC()
+	if got := facade.AsV7Time(); !got.Equal(want) {
+		t.Errorf("AsV7Time(façade): got %v, want %v", got, want)
+	}
+	if facade.AsV7Time().Equal(created) {
+		t.Error("AsV7Time(façade) should not reveal the creation time")
+	}
+}
+
+func TestClampTime(t *testing.T) {
+	lo := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	hi := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		ts   uint64
+		want time.Time
+	}{
+		{"pre-epoch", 0, lo},
+		{"before", uint64(lo.UnixMilli()) - 1, lo},
+		{"inside", 1704067200000, time.UnixMilli(1704067200000).UTC()},
+		{"after", uint64(hi.UnixMilli()) + 1, hi},
+		{"far-future", 0x0000FFFFFFFFFFFF, hi},
+	}
+	for _, tt := range tests {
+		u := NewV7Exact(tt.ts, 0x0ABC, 0x0123456789ABCDEF)
+		orig := u
+		u.ClampTime(lo, hi)
+		if got := u.AsV7Time(); !got.Equal(tt.want) {
+			t.Errorf("ClampTime(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+		if [10]byte(u[6:]) != [10]byte(orig[6:]) {
+			t.Errorf("ClampTime(%s) changed version, variant or random bits", tt.name)
+		}
+	}
+
+	// Bounds outside the 48-bit range clamp to it
+	u := NewV7Exact(1000, 1, 2)
+	u.ClampTime(time.UnixMilli(-5), time.UnixMilli(1<<50))
+	if rd48be(u[0:6]) != 1000 {
+		t.Errorf("ClampTime() with wide bounds changed the timestamp: %d", rd48be(u[0:6]))
+	}
+}
+
+func TestTimestampAfter(t *testing.T) {
+	u := NewV7Exact(1704067200000, 0x0FFF, 0x3FFFFFFFFFFFFFFF)
+	tests := []struct {
+		watermark uint64
+		want      bool
+	}{
+		{0, true},
+		{1704067199999, true},
+		{1704067200000, false},
+		{1704067200001, false},
+		{^uint64(0), false},
+	}
+	for _, tt := range tests {
+		if got := u.TimestampAfter(tt.watermark); got != tt.want {
+			t.Errorf("TimestampAfter(%d): got %v, want %v", tt.watermark, got, tt.want)
+		}
+	}
+}
+
+func TestTimeUnit(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		count uint64
+		unit  time.Duration
+		want  time.Time
+	}{
+		{"milliseconds", uint64(created.UnixMilli()) + 7, time.Millisecond, created.Add(7 * time.Millisecond)},
+		{"seconds", uint64(created.Unix()), time.Second, created},
+		{"microseconds", 1_500_000, time.Microsecond, time.Unix(1, 5e8).UTC()},
+		{"100ns", 10, 100 * time.Nanosecond, time.Unix(0, 1000).UTC()},
+		{"minutes", 0x0000FFFFFFFFFFFF, time.Minute, time.Unix(0x0000FFFFFFFFFFFF*60, 0).UTC()},
+		{"max-ms", 0x0000FFFFFFFFFFFF, time.Millisecond, time.UnixMilli(0x0000FFFFFFFFFFFF).UTC()},
+		{"zero-unit", 1, 0, time.Time{}},
+		{"negative-unit", 1, -time.Second, time.Time{}},
+		{"too-long", 1, 2 * time.Hour, time.Time{}},
+	}
+	for _, tt := range tests {
+		u := NewV7Exact(tt.count, 0x0ABC, 0x0123456789ABCDEF)
+		if got := u.TimeUnit(tt.unit); !got.Equal(tt.want) {
+			t.Errorf("TimeUnit(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	u := NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF)
+	if !u.TimeUnit(time.Millisecond).Equal(u.AsV7Time()) {
+		t.Error("TimeUnit(time.Millisecond) should equal AsV7Time()")
+	}
+}
+
+func TestFacadeDateKey(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 23, 59, 59, 999e6, time.UTC)
+	facade := Encode(NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF), key)
+
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"20060102", "20240501"},
+		{"2006-01", "2024-05"},
+		{time.RFC3339Nano, "2024-05-01T23:59:59.999Z"},
+	}
+	for _, tt := range tests {
+		if got := FacadeDateKey(facade, key, tt.layout); got != tt.want {
+			t.Errorf("FacadeDateKey(%q): got %s, want %s", tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestMonotonicKey(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		want uint64
+	}{
+		{"zero", NewV7Exact(0, 0, 0x3FFFFFFFFFFFFFFF), 0},
+		{"counter", NewV7Exact(1, 0x0ABC, 0), 1<<12 | 0x0ABC},
+		{"max", NewV7Exact(0x0000FFFFFFFFFFFF, 0x0FFF, 0), 1<<60 - 1},
+	}
+	for _, tt := range tests {
+		if got := tt.u.MonotonicKey(); got != tt.want {
+			t.Errorf("MonotonicKey(%s): got 0x%X, want 0x%X", tt.name, got, tt.want)
+		}
+	}
+
+	ids := []UUID{
+		NewV7Exact(1000, 0, 9),
+		NewV7Exact(1000, 1, 0),
+		NewV7Exact(1000, 0x0FFF, 0),
+		NewV7Exact(1001, 0, 0),
+	}
+	for i := range ids[1:] {
+		a, b := ids[i].MonotonicKey(), ids[i+1].MonotonicKey()
+		if a >= b || !ids[i].Before(ids[i+1]) {
+			t.Errorf("MonotonicKey(%d) should order before MonotonicKey(%d)", i, i+1)
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	u := NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF)
+
+	ts, randA, randB := u.Decompose()
+	if !ts.Equal(created) || ts.Location() != time.UTC {
+		t.Errorf("Decompose() time: got %v, want %v", ts, created)
+	}
+	if randA != 0x0ABC {
+		t.Errorf("Decompose() randA: got 0x%X, want 0xABC", randA)
+	}
+	if randB != 0x0123456789ABCDEF {
+		t.Errorf("Decompose() randB: got 0x%X, want 0x123456789ABCDEF", randB)
+	}
+	if back := NewV7Exact(uint64(ts.UnixMilli()), randA, randB); back != u {
+		t.Errorf("Decompose() round trip: got %v, want %v", back, u)
+	}
+}
+
+func TestToV6(t *testing.T) {
+	// RFC 9562 test vectors for 2022-02-22T19:22:22Z: the v7 is A.6 and the
+	// time fields of the result must match the v6 in A.5
+	tests := []struct {
+		v7   string
+		want string
+	}{
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", "1ec9414c-232a-6b00-98c4-dc0c0c07398f"},
+		{"00000000-0000-7000-8000-000000000000", "1b21dd21-3814-6000-8000-000000000000"},
+	}
+	for _, tt := range tests {
+		v7, err := Parse(tt.v7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v6 := v7.ToV6()
+		if got := v6.String(); got != tt.want {
+			t.Errorf("ToV6(%s): got %s, want %s", tt.v7, got, tt.want)
+		}
+		if v6.Version() != 6 {
+			t.Errorf("ToV6(%s) version: got %d, want 6", tt.v7, v6.Version())
+		}
+	}
+
+	rfcV6, _ := Parse("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	v7, _ := Parse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if got := v7.ToV6(); !bytes.Equal(got[:8], rfcV6[:8]) {
+		t.Errorf("ToV6(RFC A.6) time fields: got %x, want %x", got[:8], rfcV6[:8])
+	}
+
+	// rand_a does not leak into the timestamp
+	a, b := NewV7Exact(1704067200000, 1, 9), NewV7Exact(1704067200000, 0xFFF, 9)
+	if av6, bv6 := a.ToV6(), b.ToV6(); av6 != bv6 {
+		t.Errorf("ToV6() with different rand_a: got %v and %v, want equal", av6, bv6)
+	}
+
+	v4 := NewV4FromSeed(0)
+	if got := v4.ToV6(); !got.IsZero() {
+		t.Errorf("ToV6(v4): got %v, want zero UUID", got)
+	}
+}
+
+func TestTimePrecision(t *testing.T) {
+	tests := []struct {
+		ver  int
+		want time.Duration
+	}{
+		{1, 100 * time.Nanosecond},
+		{2, 429496729600 * time.Nanosecond},
+		{3, 0},
+		{4, 0},
+		{5, 0},
+		{6, 100 * time.Nanosecond},
+		{7, time.Millisecond},
+		{8, 0},
+	}
+	for _, tt := range tests {
+		u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+		u.SetVersion(tt.ver)
+		if got := u.TimePrecision(); got != tt.want {
+			t.Errorf("TimePrecision(v%d): got %v, want %v", tt.ver, got, tt.want)
+		}
+	}
+
+	// Converting a v7 to v6 gains precision the source never had
+	v7 := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	v6 := v7.ToV6()
+	if v6.TimePrecision() >= v7.TimePrecision() {
+		t.Error("TimePrecision() of ToV6 output should be finer than the v7")
+	}
+	if Nil.TimePrecision() != 0 || Max.TimePrecision() != 0 {
+		t.Error("TimePrecision() should be 0 for Nil and Max")
+	}
+}
+
+func TestSuspectDoubleEncoded(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	withTimeNow(t, now)
+
+	missed, flagged := 0, 0
+	for i := range uint64(200) {
+		v7 := NewV7Exact(uint64(now.UnixMilli())-i*1000, uint16(i), i*0x9e3779b97f4a7c15)
+		once := Encode(v7, key)
+		twice := Encode(once, key)
+
+		if !SuspectDoubleEncoded(twice, key, time.Minute) {
+			missed++
+		}
+		if SuspectDoubleEncoded(once, key, time.Minute) {
+			flagged++
+		}
+	}
+	// Either way the heuristic errs only when a masked timestamp happens to
+	// fall before now, about 0.6% of the 48-bit range
+	if missed > 10 {
+		t.Errorf("SuspectDoubleEncoded() missed %d of 200 double encodings", missed)
+	}
+	if flagged > 10 {
+		t.Errorf("SuspectDoubleEncoded() flagged %d of 200 genuine façades", flagged)
+	}
+}
+
+func TestFacadeIsRecent(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	facade := Encode(NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF), key)
+	window := time.Minute
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"same", created, true},
+		{"inside", created.Add(30 * time.Second), true},
+		{"window-edge", created.Add(window), true},
+		{"stale", created.Add(window + time.Millisecond), false},
+		{"future", created.Add(-time.Millisecond), false},
+	}
+	for _, tt := range tests {
+		if got := FacadeIsRecent(facade, key, window, tt.now); got != tt.want {
+			t.Errorf("FacadeIsRecent(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimeIn(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	u := NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF)
+
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	got := u.TimeIn(loc)
+	if !got.Equal(created) {
+		t.Errorf("TimeIn(): got %v, want the same instant as %v", got, created)
+	}
+	if got.Location() != loc || got.Hour() != 7 {
+		t.Errorf("TimeIn(): got %v, want 07:00 in %v", got, loc)
+	}
+	if got := u.TimeIn(time.UTC); got != u.AsV7Time() {
+		t.Errorf("TimeIn(time.UTC): got %v, want AsV7Time() %v", got, u.AsV7Time())
+	}
+}
+
+func TestExpiringFacade(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	withTimeNow(t, created)
+	ttl := 15 * time.Minute
+
+	facade, err := NewExpiringFacade(key, ttl)
+	if err != nil {
+		t.Fatalf("NewExpiringFacade() error: %v", err)
+	}
+	if facade.Version() != 4 {
+		t.Errorf("NewExpiringFacade() version: got %d, want 4", facade.Version())
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantErr error
+	}{
+		{"fresh", created, nil},
+		{"at-ttl", created.Add(ttl), nil},
+		{"expired", created.Add(ttl + time.Millisecond), ErrExpired},
+	}
+	for _, tt := range tests {
+		if err := ValidateExpiringFacade(facade, key, ttl, tt.now); err != tt.wantErr {
+			t.Errorf("ValidateExpiringFacade(%s): got %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		if u, err := NewExpiringFacade(key, ttl); err != ErrExpired || !u.IsZero() {
+			t.Errorf("NewExpiringFacade(ttl %v): got %v, %v, want zero UUID, %v", ttl, u, err, ErrExpired)
+		}
+	}
+}
+
+func TestOrderToken(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	// A migration batch mixing v7s and façades, in creation order
+	var mixed []UUID
+	for i := range uint64(20) {
+		v7 := NewV7Exact(1704067200000+i*1000, uint16(i), i*0x9e3779b97f4a7c15)
+		if i%2 == 0 {
+			mixed = append(mixed, v7)
+		} else {
+			mixed = append(mixed, Encode(v7, key))
+		}
+		if got := OrderToken(mixed[i], key); got != 1704067200000+i*1000 {
+			t.Errorf("OrderToken(%d): got %d, want %d", i, got, 1704067200000+i*1000)
+		}
+	}
+	for i := range mixed[1:] {
+		if OrderToken(mixed[i], key) >= OrderToken(mixed[i+1], key) {
+			t.Errorf("OrderToken(%d) should order before OrderToken(%d)", i, i+1)
+		}
+	}
+}
+
+func TestExportToken(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	base := uint64(1704067200000) // 2024-01-01T00:00:00Z, an hour boundary
+
+	// Tokens order by hour and carry the hour index in the high bits
+	var prevMax uint64
+	for h := range uint64(4) {
+		var lo, hi uint64 = ^uint64(0), 0
+		ordered := true
+		var last uint64
+		for i := range uint64(50) {
+			v7 := NewV7Exact(base+h*msPerHour+i*60000, uint16(i), i*0x9e3779b97f4a7c15)
+			tok, err := ExportToken(v7, key, time.Hour)
+			if err != nil {
+				t.Fatalf("ExportToken() error: %v", err)
+			}
+			if tok>>37 != base/msPerHour+h {
+				t.Errorf("ExportToken() bucket bits: got %d, want %d", tok>>37, base/msPerHour+h)
+			}
+			if again, _ := ExportToken(v7, key, time.Hour); again != tok {
+				t.Error("ExportToken() should be stable")
+			}
+			if i > 0 && tok < last {
+				ordered = false
+			}
+			last = tok
+			lo, hi = min(lo, tok), max(hi, tok)
+		}
+		if h > 0 && lo <= prevMax {
+			t.Errorf("ExportToken() hour %d should sort after hour %d", h, h-1)
+		}
+		if ordered {
+			t.Errorf("ExportToken() hour %d kept creation order within the bucket", h)
+		}
+		prevMax = hi
+	}
+
+	v7 := NewV7Exact(base, 1, 2)
+	a, _ := ExportToken(v7, key, time.Hour)
+	b, _ := ExportToken(v7, Key{K0: key.K1, K1: key.K0}, time.Hour)
+	if a == b {
+		t.Error("ExportToken() should depend on the key")
+	}
+
+	tests := []struct {
+		name    string
+		u       UUID
+		bucket  time.Duration
+		wantErr error
+	}{
+		{"zero", v7, 0, ErrInvalidBucket},
+		{"negative", v7, -time.Hour, ErrInvalidBucket},
+		{"sub-ms", v7, time.Microsecond, ErrInvalidBucket},
+		{"fractional-ms", v7, 1500 * time.Microsecond, ErrInvalidBucket},
+		{"v4", Encode(v7, key), time.Hour, ErrInvalidVersion},
+		{"ms", v7, time.Millisecond, nil},
+	}
+	for _, tt := range tests {
+		if _, err := ExportToken(tt.u, key, tt.bucket); err != tt.wantErr {
+			t.Errorf("ExportToken(%s): got %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
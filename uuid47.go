@@ -6,7 +6,11 @@
 package uuid47
 
 import (
+	"encoding/base64"
 	"errors"
+	"math/big"
+	"strconv"
+	"time"
 )
 
 // UUID version constants
@@ -22,6 +26,33 @@ var (
 	ErrInvalidHex       = errors.New("uuid47: invalid hex character")
 	ErrInvalidVersion   = errors.New("uuid47: invalid UUID version")
 	ErrInvalidByteSlice = errors.New("uuid47: invalid byte slice length")
+	ErrInvalidVariant   = errors.New("uuid47: invalid UUID variant")
+	ErrExpired          = errors.New("uuid47: façade expired")
+	ErrChecksumMismatch = errors.New("uuid47: checksum mismatch")
+	ErrSpecialUUID      = errors.New("uuid47: Nil or Max UUID not allowed")
+	ErrWeakKey          = errors.New("uuid47: weak key")
+	ErrDuplicateKey     = errors.New("uuid47: duplicate key")
+	ErrRecordMismatch   = errors.New("uuid47: record time does not match façade")
+	ErrInvalidBucket    = errors.New("uuid47: invalid bucket duration")
+)
+
+// Kind classifies a UUID as one of the RFC 9562 special values or a versioned UUID
+type Kind int
+
+// Kind constants
+const (
+	KindVersioned Kind = iota // Regular UUID, see Version()
+	KindNil                   // Nil UUID (all zeros)
+	KindMax                   // Max UUID (all ones)
+)
+
+// Special UUIDs defined in RFC 9562
+var (
+	Nil = UUID{}
+	Max = UUID{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
 )
 
 // UUID is a 128 bit (16 byte) Universal Unique IDentifier as defined in RFC 9562.
@@ -55,17 +86,48 @@ func rd48be(src []byte) uint64 {
 		uint64(src[3])<<16 | uint64(src[4])<<8 | uint64(src[5])<<0
 }
 
+// rd64be reads a 64-bit big-endian value from a byte slice
+func rd64be(src []byte) uint64 {
+	return uint64(src[0])<<56 | uint64(src[1])<<48 | uint64(src[2])<<40 |
+		uint64(src[3])<<32 | uint64(src[4])<<24 | uint64(src[5])<<16 |
+		uint64(src[6])<<8 | uint64(src[7])
+}
+
+// wr64be writes a 64-bit big-endian value to a byte slice
+func wr64be(dst []byte, v uint64) {
+	wr48be(dst[0:6], v>>16)
+	dst[6] = byte(v >> 8)
+	dst[7] = byte(v)
+}
+
 // rotl64 rotates a 64-bit value left by b bits
 func rotl64(x uint64, b uint) uint64 {
 	return (x << b) | (x >> (64 - b))
 }
 
+// sipState is the SipHash-2-4 state after key initialization
+type sipState struct {
+	v0, v1, v2, v3 uint64
+}
+
+// newSipState initializes the SipHash-2-4 state for a key
+func newSipState(k0, k1 uint64) sipState {
+	return sipState{
+		v0: uint64(0x736f6d6570736575) ^ k0,
+		v1: uint64(0x646f72616e646f6d) ^ k1,
+		v2: uint64(0x6c7967656e657261) ^ k0,
+		v3: uint64(0x7465646279746573) ^ k1,
+	}
+}
+
 // siphash24 implements SipHash-2-4 (reference implementation)
 func siphash24(in []byte, k0, k1 uint64) uint64 {
-	v0 := uint64(0x736f6d6570736575) ^ k0
-	v1 := uint64(0x646f72616e646f6d) ^ k1
-	v2 := uint64(0x6c7967656e657261) ^ k0
-	v3 := uint64(0x7465646279746573) ^ k1
+	return newSipState(k0, k1).sum(in)
+}
+
+// sum computes SipHash-2-4 of in starting from an initialized state
+func (s sipState) sum(in []byte) uint64 {
+	v0, v1, v2, v3 := s.v0, s.v1, s.v2, s.v3
 
 	inlen := len(in)
 	end := inlen &^ 7
@@ -165,6 +227,99 @@ func (u *UUID) Version() int {
 	return int(u[6]>>4) & 0x0F
 }
 
+// Kind reports whether the UUID is the Nil UUID, the Max UUID or a regular
+// versioned UUID. Check Kind before Version when the special values matter,
+// since Version reports 0 for Nil and 15 for Max.
+func (u *UUID) Kind() Kind {
+	switch *u {
+	case Nil:
+		return KindNil
+	case Max:
+		return KindMax
+	}
+	return KindVersioned
+}
+
+// Describe returns a one-line summary for logs, such as
+// "v7 created 2024-05-01T12:00:00Z" or "v4 (random or façade)". A v4 is either
+// random or a façade, which are indistinguishable without the key.
+func (u *UUID) Describe() string {
+	switch u.Kind() {
+	case KindNil:
+		return "nil UUID"
+	case KindMax:
+		return "max UUID"
+	}
+	switch ver := u.Version(); ver {
+	case Version7:
+		return "v7 created " + u.AsV7Time().Format(time.RFC3339Nano)
+	case Version4:
+		return "v4 (random or façade)"
+	default:
+		return "v" + strconv.Itoa(ver)
+	}
+}
+
+// versionNames describes the versions defined by RFC 9562, indexed by version
+var versionNames = [...]string{
+	1: "Gregorian time-based",
+	2: "DCE security",
+	3: "name-based (MD5)",
+	4: "random",
+	5: "name-based (SHA-1)",
+	6: "reordered Gregorian time-based",
+	7: "Unix time-ordered",
+	8: "custom",
+}
+
+// VersionName returns a human-readable name for the version, such as
+// "Unix time-ordered" for 7. It returns "nil" or "max" for those special
+// UUIDs and "unknown" for versions RFC 9562 does not define.
+func (u *UUID) VersionName() string {
+	switch u.Kind() {
+	case KindNil:
+		return "nil"
+	case KindMax:
+		return "max"
+	}
+	if ver := u.Version(); ver < len(versionNames) && versionNames[ver] != "" {
+		return versionNames[ver]
+	}
+	return "unknown"
+}
+
+// VariantName returns a human-readable name for the variant field, the top
+// bits of byte 8: "NCS" (0xx), "RFC 4122" (10x), "Microsoft" (110) or
+// "reserved" (111).
+func (u *UUID) VariantName() string {
+	switch {
+	case u[8]&0x80 == 0:
+		return "NCS"
+	case u[8]&0xC0 == 0x80:
+		return "RFC 4122"
+	case u[8]&0xE0 == 0xC0:
+		return "Microsoft"
+	default:
+		return "reserved"
+	}
+}
+
+// Fields splits the UUID into its RFC 9562 UUIDv7 fields: the 48-bit Unix
+// millisecond timestamp, the 12-bit rand_a, the 4-bit version, the top two
+// variant bits (2 for RFC 9562) and the 62-bit rand_b.
+func (u *UUID) Fields() (unixTsMs uint64, randA uint16, version int, variant int, randB uint64) {
+	return rd48be(u[0:6]), randA12(u), u.Version(), int(u[8] >> 6), randB62(u)
+}
+
+// HasDegenerateRandom reports whether the 74 random bits, rand_a and rand_b as
+// read by buildSipInputFromV7, are all zeros or all ones. Either pattern means
+// a broken generator whose IDs are likely to collide, and such a UUIDv7 also
+// gets the same façade mask as every other one of its kind.
+func (u *UUID) HasDegenerateRandom() bool {
+	a, b := randA12(u), randB62(u)
+	return (a == 0 && b == 0) || (a == 0x0FFF && b == 1<<62-1)
+}
+
 // SetVersion sets the UUID version
 func (u *UUID) SetVersion(ver int) {
 	u[6] = byte((u[6] & 0x0F) | byte((ver&0x0F)<<4))
@@ -202,12 +357,31 @@ func buildSipInputFromV7(u *UUID, msg *[10]byte) {
 	msg[9] = u[15]
 }
 
+// randA12 returns the 12-bit rand_a field of a UUIDv7
+func randA12(u *UUID) uint16 {
+	return uint16(u[6]&0x0F)<<8 | uint16(u[7])
+}
+
+// randB62 returns the 62-bit rand_b field of a UUIDv7
+func randB62(u *UUID) uint64 {
+	v := uint64(u[8] & 0x3F)
+	for i := 9; i < 16; i++ {
+		v = v<<8 | uint64(u[i])
+	}
+	return v
+}
+
+// timestampMask derives the 48-bit timestamp mask from the random bits of u
+func timestampMask(u *UUID, key Key) uint64 {
+	var sipmsg [10]byte
+	buildSipInputFromV7(u, &sipmsg)
+	return siphash24(sipmsg[:], key.K0, key.K1) & 0x0000FFFFFFFFFFFF
+}
+
 // Encode encodes a UUIDv7 as a UUIDv4 façade using the given key
 func Encode(v7 UUID, key Key) UUID {
 	// 1) mask = SipHash24(key, v7.random74bits) -> take low 48 bits
-	var sipmsg [10]byte
-	buildSipInputFromV7(&v7, &sipmsg)
-	mask48 := siphash24(sipmsg[:], key.K0, key.K1) & 0x0000FFFFFFFFFFFF
+	mask48 := timestampMask(&v7, key)
 
 	// 2) encTS = ts ^ mask
 	ts48 := rd48be(v7[0:6])
@@ -221,12 +395,19 @@ func Encode(v7 UUID, key Key) UUID {
 	return out
 }
 
+// EncodeToString encodes the UUIDv7 receiver as a façade and returns its
+// canonical string, formatting on the stack so only the string allocates
+func (u UUID) EncodeToString(key Key) string {
+	facade := Encode(u, key)
+	var buf [36]byte
+	facade.formatInto(&buf, hexLower)
+	return string(buf[:])
+}
+
 // Decode decodes a UUIDv4 façade back to UUIDv7 using the given key
 func Decode(v4facade UUID, key Key) UUID {
 	// 1) rebuild same Sip input from façade (identical bytes)
-	var sipmsg [10]byte
-	buildSipInputFromV7(&v4facade, &sipmsg)
-	mask48 := siphash24(sipmsg[:], key.K0, key.K1) & 0x0000FFFFFFFFFFFF
+	mask48 := timestampMask(&v4facade, key)
 
 	// 2) ts = encTS ^ mask
 	encTS := rd48be(v4facade[0:6])
@@ -240,6 +421,83 @@ func Decode(v4facade UUID, key Key) UUID {
 	return out
 }
 
+// DecodeTolerant decodes a UUIDv4 façade whose variant bits may have been
+// stripped or rewritten in transit. The SipHash input only takes the low six
+// bits of byte 8 (see buildSipInputFromV7), so the mask never depended on the
+// variant bits; this is Decode, which restores the RFC variant on output.
+func DecodeTolerant(facade UUID, key Key) UUID {
+	return Decode(facade, key)
+}
+
+// SameUnderlying reports whether two façades decode to the same UUIDv7 under
+// key. The decoded values stay in locals and are never returned, so the
+// plaintext UUIDv7 does not escape.
+func SameUnderlying(a, b UUID, key Key) bool {
+	da, db := Decode(a, key), Decode(b, key)
+	return da == db
+}
+
+// VerifyFacade re-encodes v7 under key and reports whether the result matches
+// facade. A false result flags a corrupted cache entry or a key mismatch.
+func VerifyFacade(v7, facade UUID, key Key) bool {
+	return Encode(v7, key) == facade
+}
+
+// FacadeMatchesV7 parses the claimed UUIDv7 string v7Str and reports whether
+// it encodes to facade under key. A malformed v7Str returns the Parse error.
+func FacadeMatchesV7(facade UUID, v7Str string, key Key) (bool, error) {
+	var v7 UUID
+	if err := ParseInto(v7Str, &v7); err != nil {
+		return false, err
+	}
+	return VerifyFacade(v7, facade, key), nil
+}
+
+// EncodeDiff returns the byte-wise XOR of v7 and its façade under key, for
+// auditing the masking scope. For a UUIDv7 input, bytes 0-5 hold the 48-bit
+// mask, byte 6 differs only in the version nibble (0x30) and bytes 7-15 are
+// zero.
+func EncodeDiff(v7 UUID, key Key) [16]byte {
+	facade := Encode(v7, key)
+	var diff [16]byte
+	for i := range diff {
+		diff[i] = v7[i] ^ facade[i]
+	}
+	return diff
+}
+
+// tweakedTimestampMask derives the 48-bit timestamp mask from the random bits
+// of u followed by the little-endian tweak
+func tweakedTimestampMask(u *UUID, key Key, tweak uint64) uint64 {
+	var sipmsg [18]byte
+	buildSipInputFromV7(u, (*[10]byte)(sipmsg[:10]))
+	for i := range 8 {
+		sipmsg[10+i] = byte(tweak >> (8 * i))
+	}
+	return siphash24(sipmsg[:], key.K0, key.K1) & 0x0000FFFFFFFFFFFF
+}
+
+// applyMask XORs the timestamp of u with mask48 and sets the version and RFC variant
+func applyMask(u UUID, mask48 uint64, ver int) UUID {
+	wr48be(u[0:6], rd48be(u[0:6])^mask48)
+	u.setVersion(ver)
+	u.setVariantRFC4122()
+	return u
+}
+
+// EncodeTweaked encodes a UUIDv7 as a UUIDv4 façade using the given key and
+// a tweak for domain separation. Each tweak yields an independent mask stream,
+// so façades from one namespace do not decode meaningfully in another.
+// The output is not compatible with Encode, even for a zero tweak.
+func EncodeTweaked(v7 UUID, key Key, tweak uint64) UUID {
+	return applyMask(v7, tweakedTimestampMask(&v7, key, tweak), 4)
+}
+
+// DecodeTweaked decodes a UUIDv4 façade made by EncodeTweaked back to UUIDv7
+func DecodeTweaked(v4facade UUID, key Key, tweak uint64) UUID {
+	return applyMask(v4facade, tweakedTimestampMask(&v4facade, key, tweak), 7)
+}
+
 // hexval converts a hex character to its value
 func hexval(c byte) int {
 	switch {
@@ -253,40 +511,128 @@ func hexval(c byte) int {
 	return -1
 }
 
-// Precomputed positions for each byte in the UUID string
-var uuidBytePositions = [16]int{
-	0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34,
+// Byte-lane constants for word-at-a-time hex decoding
+const (
+	lanesLSB = 0x0101010101010101
+	lanesMSB = 0x8080808080808080
+)
+
+// le64 loads 8 bytes of s as a little-endian word
+func le64[T ~string | ~[]byte](s T) uint64 {
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 |
+		uint64(s[3])<<24 | uint64(s[4])<<32 | uint64(s[5])<<40 |
+		uint64(s[6])<<48 | uint64(s[7])<<56
+}
+
+// le32 loads 4 bytes of s as a little-endian word
+func le32[T ~string | ~[]byte](s T) uint64 {
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24
+}
+
+// lanesInRange sets the high bit of each byte lane of x that lies in [lo, hi].
+// Every lane of x must be below 0x80 so the additions never carry across lanes.
+func lanesInRange(x uint64, lo, hi byte) uint64 {
+	return (x + lanesLSB*uint64(0x80-lo)) &^ (x + lanesLSB*uint64(0x7F-hi)) & lanesMSB
+}
+
+// decodeHexWord decodes 8 hex digits packed little-endian in x. Byte k of the
+// result is at bits 16k..16k+7. It returns false if any digit is not hex, or
+// is uppercase when allowUpper is not set.
+func decodeHexWord(x uint64, allowUpper bool) (uint64, bool) {
+	if x&lanesMSB != 0 {
+		return 0, false
+	}
+	digits := lanesInRange(x, '0', '9')
+	letters := lanesInRange(x, 'a', 'f')
+	if allowUpper {
+		letters |= lanesInRange(x, 'A', 'F')
+	}
+	if digits|letters != lanesMSB {
+		return 0, false
+	}
+
+	// Nibble value per lane: low four bits, plus 9 for letters
+	v := x&(lanesLSB*0x0F) + (letters>>7)*9
+
+	// Pack pairs of lanes; the first digit is the high nibble
+	return (v&0x00FF00FF00FF00FF)<<4 | (v>>8)&0x00FF00FF00FF00FF, true
 }
 
 // Parse parses a UUID string in canonical format (8-4-4-4-12)
 func Parse(s string) (UUID, error) {
+	var out UUID
+	if err := ParseInto(s, &out); err != nil {
+		return UUID{}, err
+	}
+	return out, nil
+}
+
+// ParseInto parses a UUID string in canonical format (8-4-4-4-12) directly
+// into out. It performs the same validation as Parse. On error, out is left
+// unchanged.
+func ParseInto(s string, out *UUID) error {
+	return decodeCanonical(s, out, true)
+}
+
+// ParseBytes parses a UUID in canonical format (8-4-4-4-12) from a byte
+// slice, such as a JSON token or network buffer, without converting it to a
+// string. It performs the same validation as Parse and does not retain b.
+func ParseBytes(b []byte) (UUID, error) {
+	var out UUID
+	if err := decodeCanonical(b, &out, true); err != nil {
+		return UUID{}, err
+	}
+	return out, nil
+}
+
+// decodeCanonical is the single parser behind every canonical-format entry
+// point. It decodes src into out, rejecting uppercase hex digits unless
+// allowUpper is set. It is generic so strings and byte slices share the
+// layout logic without a conversion copy.
+func decodeCanonical[T ~string | ~[]byte](s T, out *UUID, allowUpper bool) error {
 	if len(s) != 36 {
-		return UUID{}, ErrInvalidLength
+		return ErrInvalidLength
 	}
 
 	// Check dash positions
 	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
-		return UUID{}, ErrInvalidFormat
+		return ErrInvalidFormat
 	}
 
-	var out UUID
-	for i := range 16 {
-		pos := uuidBytePositions[i]
-		h := hexval(s[pos])
-		l := hexval(s[pos+1])
-		if h < 0 || l < 0 {
-			return UUID{}, ErrInvalidHex
+	// Decode the 32 hex digits as four words of eight, skipping the dashes
+	words := [4]uint64{
+		le64(s[0:8]),
+		le32(s[9:13]) | le32(s[14:18])<<32,
+		le32(s[19:23]) | le32(s[24:28])<<32,
+		le64(s[28:36]),
+	}
+	for i, x := range words {
+		v, ok := decodeHexWord(x, allowUpper)
+		if !ok {
+			return ErrInvalidHex
 		}
-		out[i] = byte((h << 4) | l)
+		words[i] = v
 	}
-	return out, nil
+
+	// Write out only once every word is valid, so it is untouched on error
+	for i, v := range words {
+		out[4*i] = byte(v)
+		out[4*i+1] = byte(v >> 16)
+		out[4*i+2] = byte(v >> 32)
+		out[4*i+3] = byte(v >> 48)
+	}
+	return nil
 }
 
-// String returns the UUID in canonical format (8-4-4-4-12)
-func (u *UUID) String() string {
-	var buf [36]byte
-	hexd := "0123456789abcdef"
-	dpos := []int{4, 6, 8, 10}
+// Hex digit tables for formatting
+const (
+	hexLower = "0123456789abcdef"
+	hexUpper = "0123456789ABCDEF"
+)
+
+// formatInto writes the canonical format (8-4-4-4-12) into buf using the hex digits in hexd
+func (u *UUID) formatInto(buf *[36]byte, hexd string) {
+	dpos := [4]int{4, 6, 8, 10}
 
 	j := 0
 	for i := range 16 {
@@ -299,14 +645,111 @@ func (u *UUID) String() string {
 		buf[j] = hexd[u[i]&0xF]
 		j++
 	}
+}
+
+// String returns the UUID in canonical format (8-4-4-4-12)
+func (u *UUID) String() string {
+	var buf [36]byte
+	u.formatInto(&buf, hexLower)
+	return string(buf[:])
+}
+
+// PGString returns the UUID exactly as PostgreSQL's uuid type prints it:
+// lowercase, hyphenated 8-4-4-4-12, without braces. COPY data written with it
+// matches what a SELECT returns byte for byte. It is the same as String.
+func (u *UUID) PGString() string {
+	return u.String()
+}
+
+// redactedSuffix replaces everything after the first group in Redacted
+const redactedSuffix = "-****-****-****-************"
+
+// Redacted returns the first 8 canonical characters followed by a fixed
+// mask, e.g. 018f2d9f-****-****-****-************. It keeps enough for log
+// correlation without leaking a usable identifier.
+func (u *UUID) Redacted() string {
+	var buf [36]byte
+	u.formatInto(&buf, hexLower)
+	copy(buf[8:], redactedSuffix)
+	return string(buf[:])
+}
+
+// TimeRedacted returns the canonical form with the 48-bit timestamp shown as
+// zeros, e.g. 00000000-0000-7abc-8123-456789abcdef. The version and random
+// bits stay intact, so log lines still correlate without revealing when the
+// UUID was created.
+func (u *UUID) TimeRedacted() string {
+	var buf [36]byte
+	u.formatInto(&buf, hexLower)
+	copy(buf[:], "00000000-0000")
+	return string(buf[:])
+}
+
+// FormatUpperInto writes the uppercase canonical format (8-4-4-4-12) into buf
+// without allocating
+func (u *UUID) FormatUpperInto(buf *[36]byte) {
+	u.formatInto(buf, hexUpper)
+}
+
+// HexInto writes the 32 lowercase hex digits of the UUID, without dashes,
+// into buf without allocating
+func (u *UUID) HexInto(buf *[32]byte) {
+	for i := range 16 {
+		buf[2*i] = hexLower[u[i]>>4]
+		buf[2*i+1] = hexLower[u[i]&0xF]
+	}
+}
+
+// Hex returns the 32 lowercase hex digits of the UUID without dashes
+func (u *UUID) Hex() string {
+	var buf [32]byte
+	u.HexInto(&buf)
 	return string(buf[:])
 }
 
+// URLToken returns the 16 bytes as unpadded base64url (22 characters), a
+// URL-safe form shorter than the canonical string
+func (u *UUID) URLToken() string {
+	return base64.RawURLEncoding.EncodeToString(u[:])
+}
+
+// ParseURLToken parses a token produced by URLToken. Non-canonical tokens,
+// whose unused trailing bits are not zero, are rejected with ErrInvalidFormat.
+func ParseURLToken(s string) (UUID, error) {
+	if len(s) != 22 {
+		return UUID{}, ErrInvalidLength
+	}
+	var out UUID
+	if _, err := base64.RawURLEncoding.Strict().Decode(out[:], []byte(s)); err != nil {
+		return UUID{}, ErrInvalidFormat
+	}
+	return out, nil
+}
+
 // Bytes returns the UUID as a byte slice
 func (u *UUID) Bytes() []byte {
 	return u[:]
 }
 
+// CopyTo copies the 16 bytes of the UUID into the start of dst without
+// allocating and without aliasing the UUID, unlike Bytes. It returns
+// ErrInvalidByteSlice if dst is shorter than 16 bytes, or 16 otherwise.
+func (u *UUID) CopyTo(dst []byte) (int, error) {
+	if len(dst) < 16 {
+		return 0, ErrInvalidByteSlice
+	}
+	return copy(dst, u[:]), nil
+}
+
+// ReversedBytes returns a new byte slice with the UUID bytes in reverse order
+func (u *UUID) ReversedBytes() []byte {
+	b := make([]byte, 16)
+	for i := range 16 {
+		b[i] = u[15-i]
+	}
+	return b
+}
+
 // SetBytes sets the UUID from a byte slice
 func (u *UUID) SetBytes(b []byte) error {
 	if len(b) != 16 {
@@ -316,16 +759,138 @@ func (u *UUID) SetBytes(b []byte) error {
 	return nil
 }
 
+// ToArray returns the UUID as a plain [16]byte, e.g. to convert to google/uuid
+// or gofrs/uuid with uuid.UUID(u.ToArray())
+func (u UUID) ToArray() [16]byte {
+	return [16]byte(u)
+}
+
+// FromArray returns the UUID for a plain [16]byte, e.g. FromArray(googleID)
+func FromArray(a [16]byte) UUID {
+	return UUID(a)
+}
+
+// FromBytes returns the UUID for a raw 16-byte slice, the raw-bytes
+// counterpart to Parse. It returns ErrInvalidByteSlice for any other length.
+func FromBytes(b []byte) (UUID, error) {
+	if len(b) != 16 {
+		return UUID{}, ErrInvalidByteSlice
+	}
+	return UUID(b), nil
+}
+
+// U128 is the UUID as two big-endian uint64 halves. It is comparable, so it
+// works as a map key, and reads better than a bare pair in struct fields.
+type U128 struct {
+	Hi, Lo uint64
+}
+
+// U128 returns the UUID split into its high and low 64-bit halves
+func (u *UUID) U128() U128 {
+	return U128{Hi: rd64be(u[0:8]), Lo: rd64be(u[8:16])}
+}
+
+// UUID reassembles the UUID from its two halves
+func (c U128) UUID() UUID {
+	var u UUID
+	wr64be(u[0:8], c.Hi)
+	wr64be(u[8:16], c.Lo)
+	return u
+}
+
+// SetBytesStrict sets the UUID from a byte slice like SetBytes, but also
+// requires the RFC4122 variant bits (10xxxxxx) in byte 8. Use it at trust
+// boundaries to reject corrupted payloads. The UUID is left unchanged on error.
+func (u *UUID) SetBytesStrict(b []byte) error {
+	if len(b) != 16 {
+		return ErrInvalidByteSlice
+	}
+	if b[8]&0xC0 != 0x80 {
+		return ErrInvalidVariant
+	}
+	copy(u[:], b)
+	return nil
+}
+
 // IsZero returns true if the UUID is all zeros
 func (u *UUID) IsZero() bool {
 	return *u == UUID{}
 }
 
+// LeadingZeroBytes returns the number of leading 0x00 bytes, from 0 to 16,
+// e.g. to choose a shorter length prefix in a variable-length encoding
+func (u *UUID) LeadingZeroBytes() int {
+	for i, b := range u {
+		if b != 0 {
+			return i
+		}
+	}
+	return len(u)
+}
+
+// IsMax returns true if the UUID is all ones
+func (u *UUID) IsMax() bool {
+	return *u == Max
+}
+
 // Equal returns true if two UUIDs are equal
 func (u *UUID) Equal(other UUID) bool {
 	return *u == other
 }
 
+// EqualFold reports whether s is the canonical string of u in either hex
+// case, such as an uppercase UUID from a config file. A malformed s is not
+// an error; it just does not match.
+func (u *UUID) EqualFold(s string) bool {
+	var other UUID
+	return ParseInto(s, &other) == nil && *u == other
+}
+
+// EqualIgnoringVersionVariant returns true if two UUIDs are equal apart from
+// the version nibble (high nibble of byte 6) and the variant bits (top two
+// bits of byte 8)
+func (u *UUID) EqualIgnoringVersionVariant(other UUID) bool {
+	a, b := *u, other
+	a[6], b[6] = a[6]&0x0F, b[6]&0x0F
+	a[8], b[8] = a[8]&0x3F, b[8]&0x3F
+	return a == b
+}
+
+// Next returns the UUID one greater than u in lexical (byte) order, for
+// half-open range bounds such as [id, id.Next()). It returns Nil and false
+// if u is Max.
+func (u *UUID) Next() (UUID, bool) {
+	next := *u
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next, true
+		}
+	}
+	return UUID{}, false
+}
+
+// Prev returns the UUID one less than u in lexical (byte) order. It returns
+// Max and false if u is Nil.
+func (u *UUID) Prev() (UUID, bool) {
+	prev := *u
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 0xFF {
+			return prev, true
+		}
+	}
+	return Max, false
+}
+
+// Distance returns a - b, treating each UUID as an unsigned 128-bit
+// big-endian integer. The result is signed: it is negative when a orders
+// before b.
+func Distance(a, b UUID) *big.Int {
+	d := new(big.Int).SetBytes(a[:])
+	return d.Sub(d, new(big.Int).SetBytes(b[:]))
+}
+
 // MarshalText implements encoding.TextMarshaler
 func (u UUID) MarshalText() ([]byte, error) {
 	return []byte(u.String()), nil
@@ -333,7 +898,7 @@ func (u UUID) MarshalText() ([]byte, error) {
 
 // UnmarshalText implements encoding.TextUnmarshaler
 func (u *UUID) UnmarshalText(text []byte) error {
-	parsed, err := Parse(string(text))
+	parsed, err := ParseBytes(text)
 	if err != nil {
 		return err
 	}
@@ -8,16 +8,46 @@ import (
 	"testing"
 )
 
-// xorshift64star is a simple PRNG for benchmark data generation
-type xorshift64star uint64
-
-func (x *xorshift64star) next() uint64 {
-	v := uint64(*x)
-	v ^= v >> 12
-	v ^= v << 25
-	v ^= v >> 27
-	*x = xorshift64star(v)
-	return v * 2685821657736338717
+// uuidBytePositions holds the position of each byte in the canonical string
+var uuidBytePositions = [16]int{
+	0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34,
+}
+
+// parseHexvalLadder is the per-nibble hexval parser Parse used before the
+// word-at-a-time decoder. It is the reference for equivalence tests and the
+// baseline for BenchmarkUUIDParseHexvalLadder.
+func parseHexvalLadder(s string, allowUpper bool) (UUID, error) {
+	if len(s) != 36 {
+		return UUID{}, ErrInvalidLength
+	}
+	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return UUID{}, ErrInvalidFormat
+	}
+
+	var out UUID
+	for i := range 16 {
+		pos := uuidBytePositions[i]
+		h := hexval(s[pos])
+		l := hexval(s[pos+1])
+		if h < 0 || l < 0 {
+			return UUID{}, ErrInvalidHex
+		}
+		if !allowUpper && ('A' <= s[pos] && s[pos] <= 'F' || 'A' <= s[pos+1] && s[pos+1] <= 'F') {
+			return UUID{}, ErrInvalidHex
+		}
+		out[i] = byte((h << 4) | l)
+	}
+	return out, nil
+}
+
+// encodeNoMask performs Encode's byte juggling with a zero mask, skipping
+// SipHash. It is the baseline for BenchmarkEncodeVsBaseline.
+func encodeNoMask(v7 UUID) UUID {
+	out := v7
+	wr48be(out[0:6], rd48be(v7[0:6]))
+	out.setVersion(4)
+	out.setVariantRFC4122()
+	return out
 }
 
 // BenchmarkEncodeDecodePair benchmarks the full encode+decode roundtrip
@@ -43,7 +73,7 @@ func BenchmarkEncodeDecodePair(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		d := data[i&1023]
-		u7 := craftV7(d.ts, d.ra, d.rb)
+		u7 := NewV7Exact(d.ts, d.ra, d.rb)
 		facade := Encode(u7, key)
 		back := Decode(facade, key)
 
@@ -65,7 +95,7 @@ func BenchmarkEncode(b *testing.B) {
 		ts := rng.next() & 0x0000FFFFFFFFFFFF
 		ra := uint16(rng.next() & 0x0FFF)
 		rb := rng.next() & ((1 << 62) - 1)
-		uuids[i] = craftV7(ts, ra, rb)
+		uuids[i] = NewV7Exact(ts, ra, rb)
 	}
 
 	b.ResetTimer()
@@ -80,6 +110,69 @@ func BenchmarkEncode(b *testing.B) {
 	}
 }
 
+// BenchmarkEncodeVsBaseline reports Encode next to a path that skips
+// SipHash, so the difference is the marginal cost of masking
+func BenchmarkEncodeVsBaseline(b *testing.B) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+
+	// Pre-generate v7 UUIDs
+	uuids := make([]UUID, 1024)
+	for i := range uuids {
+		ts := rng.next() & 0x0000FFFFFFFFFFFF
+		ra := uint16(rng.next() & 0x0FFF)
+		rb := rng.next() & ((1 << 62) - 1)
+		uuids[i] = NewV7Exact(ts, ra, rb)
+	}
+
+	b.Run("Encode", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			facade := Encode(uuids[i&1023], key)
+
+			// Prevent dead code elimination
+			if facade[0] == 0xFF && facade[15] == 0xFF {
+				b.Fatal("unexpected")
+			}
+		}
+	})
+	b.Run("Baseline", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			out := encodeNoMask(uuids[i&1023])
+
+			// Prevent dead code elimination
+			if out[0] == 0xFF && out[15] == 0xFF {
+				b.Fatal("unexpected")
+			}
+		}
+	})
+}
+
+// BenchmarkKeyScheduleEncode benchmarks encode with a precomputed key schedule
+func BenchmarkKeyScheduleEncode(b *testing.B) {
+	ks := NewKeySchedule(Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210})
+	rng := xorshift64star(0x9e3779b97f4a7c15)
+
+	// Pre-generate v7 UUIDs
+	uuids := make([]UUID, 1024)
+	for i := range uuids {
+		ts := rng.next() & 0x0000FFFFFFFFFFFF
+		ra := uint16(rng.next() & 0x0FFF)
+		rb := rng.next() & ((1 << 62) - 1)
+		uuids[i] = NewV7Exact(ts, ra, rb)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u7 := uuids[i&1023]
+		facade := ks.Encode(u7)
+
+		// Prevent dead code elimination
+		if facade[0] == 0xFF && facade[15] == 0xFF {
+			b.Fatal("unexpected")
+		}
+	}
+}
+
 // BenchmarkDecode benchmarks only the decode operation
 func BenchmarkDecode(b *testing.B) {
 	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
@@ -91,7 +184,7 @@ func BenchmarkDecode(b *testing.B) {
 		ts := rng.next() & 0x0000FFFFFFFFFFFF
 		ra := uint16(rng.next() & 0x0FFF)
 		rb := rng.next() & ((1 << 62) - 1)
-		u7 := craftV7(ts, ra, rb)
+		u7 := NewV7Exact(ts, ra, rb)
 		facades[i] = Encode(u7, key)
 	}
 
@@ -118,7 +211,7 @@ func BenchmarkSipHash24_10B(b *testing.B) {
 		ts := rng.next() & 0x0000FFFFFFFFFFFF
 		ra := uint16(rng.next() & 0x0FFF)
 		rb := rng.next() & ((1 << 62) - 1)
-		u7 := craftV7(ts, ra, rb)
+		u7 := NewV7Exact(ts, ra, rb)
 		buildSipInputFromV7(&u7, &messages[i])
 	}
 
@@ -151,6 +244,23 @@ func BenchmarkUUIDParse(b *testing.B) {
 	}
 }
 
+// BenchmarkUUIDParseHexvalLadder benchmarks the previous per-nibble parser for comparison
+func BenchmarkUUIDParseHexvalLadder(b *testing.B) {
+	s := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u, err := parseHexvalLadder(s, true)
+		if err != nil {
+			b.Fatal(err)
+		}
+		// Prevent dead code elimination
+		if u[0] == 0xFF {
+			b.Fatal("unexpected")
+		}
+	}
+}
+
 // BenchmarkUUIDString benchmarks UUID string formatting
 func BenchmarkUUIDString(b *testing.B) {
 	u := UUID{
@@ -185,3 +295,27 @@ func BenchmarkUUIDParseFormat(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkFillV7 benchmarks bulk generation against one newV7 call per UUID
+func BenchmarkFillV7(b *testing.B) {
+	dst := make([]UUID, 1024)
+
+	b.Run("FillV7", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := FillV7(dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range dst {
+				u, err := newV7()
+				if err != nil {
+					b.Fatal(err)
+				}
+				dst[j] = u
+			}
+		}
+	})
+}
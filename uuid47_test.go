@@ -7,7 +7,10 @@ package uuid47
 import (
 	"bytes"
 	"encoding/binary"
+	"math/big"
+	"sync"
 	"testing"
+	"time"
 )
 
 func leU64(b []byte) uint64 {
@@ -52,6 +55,143 @@ func TestUUIDParseFormatRoundtrip(t *testing.T) {
 	}
 }
 
+func TestParseMatchesHexvalLadder(t *testing.T) {
+	bases := []string{
+		"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f",
+		"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F",
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	}
+
+	// Substitute every byte value at every position
+	for _, base := range bases {
+		for pos := range 36 {
+			for c := range 256 {
+				s := base[:pos] + string([]byte{byte(c)}) + base[pos+1:]
+				for _, allowUpper := range []bool{true, false} {
+					want, wantErr := parseHexvalLadder(s, allowUpper)
+					got, err := ParseCase(s, allowUpper)
+					if err != wantErr || got != want {
+						t.Fatalf("ParseCase(%q, %v): got %v, %v; want %v, %v", s, allowUpper, got, err, want, wantErr)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestParseInto(t *testing.T) {
+	s := "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"
+	want, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var u UUID
+	if err := ParseInto(s, &u); err != nil {
+		t.Fatalf("ParseInto failed: %v", err)
+	}
+	if u != want {
+		t.Errorf("ParseInto mismatch: got %v, want %v", u, want)
+	}
+
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{"too-short", ErrInvalidLength},
+		{"01234567_1234_1234_1234_123456789012", ErrInvalidFormat},
+		{"01234567-1234-1234-1234-1234567890zz", ErrInvalidHex},
+	}
+	for _, tt := range tests {
+		if err := ParseInto(tt.in, &u); err != tt.want {
+			t.Errorf("ParseInto(%q): got %v, want %v", tt.in, err, tt.want)
+		}
+		if u != want {
+			t.Errorf("ParseInto(%q) left out: got %v, want %v unchanged", tt.in, u, want)
+		}
+	}
+}
+
+func TestFormatUpperInto(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	var buf [36]byte
+	u.FormatUpperInto(&buf)
+	if got, want := string(buf[:]), "018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F"; got != want {
+		t.Errorf("FormatUpperInto(): got %s, want %s", got, want)
+	}
+
+	back, err := Parse(string(buf[:]))
+	if err != nil || back != u {
+		t.Errorf("FormatUpperInto() roundtrip: got %v, %v", back, err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		u.FormatUpperInto(&buf)
+	})
+	if allocs != 0 {
+		t.Errorf("FormatUpperInto() allocs: got %v, want 0", allocs)
+	}
+}
+
+func TestHex(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	want := "018f2d9f9a2a7def8c3f7b1a2c4d5e6f"
+
+	if got := u.Hex(); got != want {
+		t.Errorf("Hex(): got %s, want %s", got, want)
+	}
+
+	var buf [32]byte
+	u.HexInto(&buf)
+	if got := string(buf[:]); got != want {
+		t.Errorf("HexInto(): got %s, want %s", got, want)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		u.HexInto(&buf)
+	})
+	if allocs != 0 {
+		t.Errorf("HexInto() allocs: got %v, want 0", allocs)
+	}
+}
+
+func TestURLToken(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	tok := u.URLToken()
+	if want := "AY8tn5oqfe-MP3saLE1ebw"; tok != want {
+		t.Errorf("URLToken(): got %s, want %s", tok, want)
+	}
+
+	back, err := ParseURLToken(tok)
+	if err != nil {
+		t.Fatalf("ParseURLToken(%q) failed: %v", tok, err)
+	}
+	if back != u {
+		t.Errorf("ParseURLToken() mismatch: got %v, want %v", back, u)
+	}
+
+	if tok := Max.URLToken(); tok != "_____________________w" {
+		t.Errorf("URLToken(Max): got %s", tok)
+	}
+
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{"AY8tn5oqfe-MP3saLE1eb", ErrInvalidLength},
+		{"AY8tn5oqfe-MP3saLE1ebw==", ErrInvalidLength},
+		{"AY8tn5oqfe+MP3saLE1ebw", ErrInvalidFormat},
+		{"AY8tn5oqfe-MP3saLE1ebx", ErrInvalidFormat}, // non-zero trailing bits
+	}
+	for _, tt := range tests {
+		if _, err := ParseURLToken(tt.in); err != tt.want {
+			t.Errorf("ParseURLToken(%q): got %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}
+
 func TestVersionVariant(t *testing.T) {
 	var u UUID
 	u.setVersion(7)
@@ -102,22 +242,8 @@ func TestSipHashSwitchAndVectorsSubset(t *testing.T) {
 	_ = siphash24(msg[:15], k0, k1)
 }
 
-func craftV7(tsMs48 uint64, randA12 uint16, randB62 uint64) UUID {
-	var u UUID
-	wr48be(u[0:6], tsMs48&0x0000FFFFFFFFFFFF)
-	u.setVersion(7)
-	u[6] = byte((u[6] & 0xF0) | byte((randA12>>8)&0x0F))
-	u[7] = byte(randA12 & 0xFF)
-	u.setVariantRFC4122()
-	u[8] = byte((u[8] & 0xC0) | byte((randB62>>56)&0x3F))
-	for i := range 7 {
-		u[9+i] = byte((randB62 >> (8 * (6 - i))) & 0xFF)
-	}
-	return u
-}
-
 func TestBuildSipInputStability(t *testing.T) {
-	u7 := craftV7(0x123456789ABC, 0x0ABC, 0x0123456789ABCDEF&((1<<62)-1))
+	u7 := NewV7Exact(0x123456789ABC, 0x0ABC, 0x0123456789ABCDEF&((1<<62)-1))
 	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
 	facade := Encode(u7, key)
 
@@ -136,7 +262,7 @@ func TestEncodeDecodeRoundtrip(t *testing.T) {
 		ts := uint64((0x100000 * i) + 123)
 		ra := uint16((0x0AAA ^ (i * 7)) & 0x0FFF)
 		rb := (0x0123456789ABCDEF ^ (0x1111111111111111 * uint64(i))) & ((1 << 62) - 1)
-		u7 := craftV7(ts, ra, rb)
+		u7 := NewV7Exact(ts, ra, rb)
 
 		facade := Encode(u7, key)
 		if facade.Version() != 4 {
@@ -160,6 +286,100 @@ func TestEncodeDecodeRoundtrip(t *testing.T) {
 	}
 }
 
+func TestDecodeTolerant(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	u7 := NewV7Exact(0x018f2d9f9a2a, 0x0def, 0x3FFFFFFFFFFFFFFF)
+	facade := Encode(u7, key)
+
+	for _, v := range []byte{0x00, 0x40, 0x80, 0xC0} {
+		stripped := facade
+		stripped[8] = (stripped[8] & 0x3F) | v
+		if back := DecodeTolerant(stripped, key); back != u7 {
+			t.Errorf("DecodeTolerant() with variant 0x%X: got %v, want %v", v, back, u7)
+		}
+		if back := Decode(stripped, key); back != u7 {
+			t.Errorf("Decode() with variant 0x%X: got %v, want %v", v, back, u7)
+		}
+	}
+}
+
+func TestEncodeDeterminism(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	// Many v7s sharing one millisecond, as a burst from one generator
+	inputs := make([]UUID, 16)
+	for i := range inputs {
+		inputs[i] = NewV7Exact(1704067200000, uint16(i), uint64(i)*0x9e3779b97f4a7c15)
+	}
+	want := make([]UUID, len(inputs))
+	for i, u7 := range inputs {
+		want[i] = Encode(u7, key)
+	}
+
+	for n := range 10000 {
+		i := n % len(inputs)
+		if got := Encode(inputs[i], key); got != want[i] {
+			t.Fatalf("Encode() iteration %d: got %v, want %v", n, got, want[i])
+		}
+	}
+
+	// Concurrent callers must see the same output; run with -race to catch
+	// any shared mutable state
+	var wg sync.WaitGroup
+	errs := make(chan UUID, 8)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 1000 {
+				i := n % len(inputs)
+				if got := Encode(inputs[i], key); got != want[i] {
+					errs <- got
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for got := range errs {
+		t.Errorf("Encode() from a goroutine: got %v, want one of the sequential results", got)
+	}
+}
+
+func TestEncodeDecodeTweaked(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	for i := range 16 {
+		ts := uint64((0x100000 * i) + 123)
+		ra := uint16((0x0AAA ^ (i * 7)) & 0x0FFF)
+		rb := (0x0123456789ABCDEF ^ (0x1111111111111111 * uint64(i))) & ((1 << 62) - 1)
+		u7 := NewV7Exact(ts, ra, rb)
+
+		fa := EncodeTweaked(u7, key, 1)
+		if fa.Version() != 4 {
+			t.Errorf("Tweaked façade version: got %d, want 4", fa.Version())
+		}
+		if (fa[8] & 0xC0) != 0x80 {
+			t.Errorf("Tweaked façade variant bits: got 0x%X, want 0x80", fa[8]&0xC0)
+		}
+		if back := DecodeTweaked(fa, key, 1); back != u7 {
+			t.Errorf("Tweaked roundtrip %d failed:\noriginal: %v\nback:     %v", i, u7, back)
+		}
+
+		fb := EncodeTweaked(u7, key, 2)
+		if fa == fb {
+			t.Errorf("Different tweaks should produce different façades")
+		}
+		if DecodeTweaked(fa, key, 2) == u7 {
+			t.Errorf("Decoding with the wrong tweak should produce a different result")
+		}
+		if EncodeTweaked(u7, key, 0) == Encode(u7, key) {
+			t.Errorf("Zero tweak should not match the untweaked façade")
+		}
+	}
+}
+
 func TestBytes(t *testing.T) {
 	u := UUID{
 		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
@@ -174,6 +394,46 @@ func TestBytes(t *testing.T) {
 	}
 }
 
+func TestReversedBytes(t *testing.T) {
+	u := UUID{
+		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
+		0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f,
+	}
+	want := []byte{
+		0x6f, 0x5e, 0x4d, 0x2c, 0x1a, 0x7b, 0x3f, 0x8c,
+		0xef, 0x7d, 0x2a, 0x9a, 0x9f, 0x2d, 0x8f, 0x01,
+	}
+	b := u.ReversedBytes()
+	if !bytes.Equal(b, want) {
+		t.Errorf("ReversedBytes() mismatch:\ngot:  %x\nwant: %x", b, want)
+	}
+
+	// The result must not alias the UUID
+	b[0] = 0x00
+	if u[15] != 0x6f {
+		t.Error("ReversedBytes() result aliases the UUID")
+	}
+}
+
+func TestArrayConversion(t *testing.T) {
+	// otherUUID stands in for google/uuid.UUID or gofrs/uuid.UUID
+	type otherUUID [16]byte
+
+	u := UUID{
+		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
+		0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f,
+	}
+	other := otherUUID(u.ToArray())
+	if !bytes.Equal(other[:], u[:]) {
+		t.Errorf("ToArray() mismatch: got %x, want %x", other, u)
+	}
+
+	back := FromArray(other)
+	if back != u {
+		t.Errorf("FromArray() mismatch: got %v, want %v", back, u)
+	}
+}
+
 func TestSetBytes(t *testing.T) {
 	b := []byte{
 		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
@@ -195,6 +455,40 @@ func TestSetBytes(t *testing.T) {
 	}
 }
 
+func TestSetBytesStrict(t *testing.T) {
+	b := []byte{
+		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
+		0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f,
+	}
+	var u UUID
+	if err := u.SetBytesStrict(b); err != nil {
+		t.Fatalf("SetBytesStrict() error: %v", err)
+	}
+	if !bytes.Equal(u[:], b) {
+		t.Errorf("SetBytesStrict() mismatch")
+	}
+
+	if err := u.SetBytesStrict([]byte{1, 2, 3}); err != ErrInvalidByteSlice {
+		t.Errorf("SetBytesStrict() with invalid length: got %v, want %v", err, ErrInvalidByteSlice)
+	}
+
+	for _, v := range []byte{0x00, 0x40, 0xC0, 0xE0} {
+		bad := bytes.Clone(b)
+		bad[8] = v | 0x0c
+		before := u
+		if err := u.SetBytesStrict(bad); err != ErrInvalidVariant {
+			t.Errorf("SetBytesStrict() with variant 0x%X: got %v, want %v", v, err, ErrInvalidVariant)
+		}
+		if u != before {
+			t.Errorf("SetBytesStrict() should not modify the UUID on error")
+		}
+		if err := u.SetBytes(bad); err != nil {
+			t.Errorf("SetBytes() should stay lenient: got %v", err)
+		}
+		u = before
+	}
+}
+
 func TestIsZero(t *testing.T) {
 	var u UUID
 	if !u.IsZero() {
@@ -229,6 +523,82 @@ func TestEqual(t *testing.T) {
 	}
 }
 
+func TestDescribe(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	u7 := NewV7Exact(uint64(created.UnixMilli()), 0x0ABC, 0x0123456789ABCDEF)
+	u7ms := NewV7Exact(uint64(created.UnixMilli())+123, 0x0ABC, 0x0123456789ABCDEF)
+	v1 := UUID{6: 0x10, 8: 0x80}
+
+	tests := []struct {
+		name string
+		u    UUID
+		want string
+	}{
+		{"v7", u7, "v7 created 2024-05-01T12:00:00Z"},
+		{"v7-ms", u7ms, "v7 created 2024-05-01T12:00:00.123Z"},
+		{"façade", Encode(u7, key), "v4 (random or façade)"},
+		{"v1", v1, "v1"},
+		{"nil", Nil, "nil UUID"},
+		{"max", Max, "max UUID"},
+	}
+	for _, tt := range tests {
+		if got := tt.u.Describe(); got != tt.want {
+			t.Errorf("Describe(%s): got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	ts, ra, ver, variant, rb := u.Fields()
+	if ts != 0x018f2d9f9a2a {
+		t.Errorf("Fields() unixTsMs: got 0x%X, want 0x018F2D9F9A2A", ts)
+	}
+	if ra != 0x0def {
+		t.Errorf("Fields() randA: got 0x%X, want 0xDEF", ra)
+	}
+	if ver != 7 {
+		t.Errorf("Fields() version: got %d, want 7", ver)
+	}
+	if variant != 2 {
+		t.Errorf("Fields() variant: got %d, want 2", variant)
+	}
+	if rb != 0x0c3f7b1a2c4d5e6f {
+		t.Errorf("Fields() randB: got 0x%X, want 0xC3F7B1A2C4D5E6F", rb)
+	}
+
+	if back := NewV7Exact(ts, ra, rb); back != u {
+		t.Errorf("Fields() should rebuild the UUID: got %v, want %v", back, u)
+	}
+}
+
+func TestEqualIgnoringVersionVariant(t *testing.T) {
+	u7, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	other := u7
+	other.SetVersion(4)
+	other[8] &= 0x3F // NCS variant
+	if !u7.EqualIgnoringVersionVariant(other) {
+		t.Error("EqualIgnoringVersionVariant() should ignore version and variant bits")
+	}
+	if u7.Equal(other) {
+		t.Error("Equal() should not ignore version and variant bits")
+	}
+
+	// Payload bits adjacent to the ignored fields must still count
+	for _, pos := range []struct {
+		i   int
+		bit byte
+	}{{6, 0x01}, {8, 0x20}, {7, 0x80}, {0, 0x01}, {15, 0x80}} {
+		changed := u7
+		changed[pos.i] ^= pos.bit
+		if u7.EqualIgnoringVersionVariant(changed) {
+			t.Errorf("EqualIgnoringVersionVariant() should detect byte %d bit 0x%X", pos.i, pos.bit)
+		}
+	}
+}
+
 func TestSetVersion(t *testing.T) {
 	var u UUID
 	u.SetVersion(7)
@@ -264,6 +634,30 @@ func TestErrorConstants(t *testing.T) {
 	if ErrInvalidByteSlice == nil {
 		t.Error("ErrInvalidByteSlice should not be nil")
 	}
+	if ErrInvalidVariant == nil {
+		t.Error("ErrInvalidVariant should not be nil")
+	}
+	if ErrExpired == nil {
+		t.Error("ErrExpired should not be nil")
+	}
+	if ErrChecksumMismatch == nil {
+		t.Error("ErrChecksumMismatch should not be nil")
+	}
+	if ErrSpecialUUID == nil {
+		t.Error("ErrSpecialUUID should not be nil")
+	}
+	if ErrWeakKey == nil {
+		t.Error("ErrWeakKey should not be nil")
+	}
+	if ErrDuplicateKey == nil {
+		t.Error("ErrDuplicateKey should not be nil")
+	}
+	if ErrRecordMismatch == nil {
+		t.Error("ErrRecordMismatch should not be nil")
+	}
+	if ErrInvalidBucket == nil {
+		t.Error("ErrInvalidBucket should not be nil")
+	}
 
 	// Test Parse returns proper errors
 	_, err := Parse("too-short")
@@ -281,3 +675,503 @@ func TestErrorConstants(t *testing.T) {
 		t.Errorf("Parse invalid format: got %v, want %v", err, ErrInvalidFormat)
 	}
 }
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		want Kind
+	}{
+		{"nil", Nil, KindNil},
+		{"max", Max, KindMax},
+		{"v7", NewV7Exact(0x123456789ABC, 0x0ABC, 0x0123456789ABCDEF), KindVersioned},
+		{"nil-with-version", UUID{6: 0x70}, KindVersioned},
+	}
+	for _, tt := range tests {
+		if got := tt.u.Kind(); got != tt.want {
+			t.Errorf("Kind(%s): got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsMax(t *testing.T) {
+	u := Max
+	if !u.IsMax() {
+		t.Error("IsMax() should return true for Max UUID")
+	}
+
+	u[15] = 0xfe
+	if u.IsMax() {
+		t.Error("IsMax() should return false for non-Max UUID")
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     UUID
+		want   UUID
+		wantOk bool
+	}{
+		{"nil", Nil, UUID{15: 0x01}, true},
+		{"no-carry", UUID{0: 0x01, 15: 0x41}, UUID{0: 0x01, 15: 0x42}, true},
+		{"carry", UUID{7: 0x01, 8: 0xFF, 9: 0xFF, 10: 0xFF, 11: 0xFF, 12: 0xFF, 13: 0xFF, 14: 0xFF, 15: 0xFF}, UUID{7: 0x02}, true},
+		{"max-minus-one", UUID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE}, Max, true},
+		{"max", Max, UUID{}, false},
+	}
+	for _, tt := range tests {
+		orig := tt.in
+		got, ok := tt.in.Next()
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("Next(%s): got %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+		if tt.in != orig {
+			t.Errorf("Next(%s) modified the receiver", tt.name)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     UUID
+		want   UUID
+		wantOk bool
+	}{
+		{"max", Max, UUID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE}, true},
+		{"no-borrow", UUID{0: 0x01, 15: 0x42}, UUID{0: 0x01, 15: 0x41}, true},
+		{"borrow", UUID{7: 0x02}, UUID{7: 0x01, 8: 0xFF, 9: 0xFF, 10: 0xFF, 11: 0xFF, 12: 0xFF, 13: 0xFF, 14: 0xFF, 15: 0xFF}, true},
+		{"one", UUID{15: 0x01}, Nil, true},
+		{"nil", Nil, Max, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.in.Prev()
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("Prev(%s): got %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+		if ok {
+			if back, _ := got.Next(); back != tt.in {
+				t.Errorf("Prev(%s).Next(): got %v, want %v", tt.name, back, tt.in)
+			}
+		}
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", "018f2d9f-****-****-****-************"},
+		{"00000000-0000-0000-0000-000000000000", "00000000-****-****-****-************"},
+		{"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", "ffffffff-****-****-****-************"},
+	}
+	for _, tt := range tests {
+		u, err := Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got := u.Redacted(); got != tt.want {
+			t.Errorf("Redacted(%s): got %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTimeRedacted(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", "00000000-0000-7def-8c3f-7b1a2c4d5e6f"},
+		{"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000000"},
+		{"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", "00000000-0000-ffff-ffff-ffffffffffff"},
+	}
+	for _, tt := range tests {
+		u, err := Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got := u.TimeRedacted(); got != tt.want {
+			t.Errorf("TimeRedacted(%s): got %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	b := []byte{
+		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
+		0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f,
+	}
+	u, err := FromBytes(b)
+	if err != nil {
+		t.Fatalf("FromBytes() error: %v", err)
+	}
+	if !bytes.Equal(u.Bytes(), b) {
+		t.Errorf("FromBytes(): got %v, want %x", u, b)
+	}
+
+	// The UUID must not alias the input slice
+	b[0] = 0xFF
+	if u[0] != 0x01 {
+		t.Error("FromBytes() should copy the input")
+	}
+
+	for _, n := range []int{0, 15, 17, 36} {
+		u, err := FromBytes(make([]byte, n))
+		if err != ErrInvalidByteSlice || !u.IsZero() {
+			t.Errorf("FromBytes(len %d): got %v, %v, want zero UUID, %v", n, u, err, ErrInvalidByteSlice)
+		}
+	}
+}
+
+func TestSameUnderlying(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	other := Key{K0: 0xfedcba9876543210, K1: 0x0123456789abcdef}
+	a := Encode(NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF), key)
+	b := Encode(NewV7Exact(1704067200001, 0x0ABC, 0x0123456789ABCDEF), key)
+
+	// A façade whose variant bits were stripped still maps to the same v7
+	stripped := a
+	stripped[8] &= 0x3F
+
+	tests := []struct {
+		name string
+		a, b UUID
+		key  Key
+		want bool
+	}{
+		{"same", a, a, key, true},
+		{"stripped-variant", a, stripped, key, true},
+		{"different", a, b, key, false},
+		{"other-key", a, Encode(Decode(a, key), other), key, false},
+	}
+	for _, tt := range tests {
+		if got := SameUnderlying(tt.a, tt.b, tt.key); got != tt.want {
+			t.Errorf("SameUnderlying(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestU128(t *testing.T) {
+	u := UUID{
+		0x01, 0x8f, 0x2d, 0x9f, 0x9a, 0x2a, 0x7d, 0xef,
+		0x8c, 0x3f, 0x7b, 0x1a, 0x2c, 0x4d, 0x5e, 0x6f,
+	}
+	want := U128{Hi: 0x018f2d9f9a2a7def, Lo: 0x8c3f7b1a2c4d5e6f}
+	if got := u.U128(); got != want {
+		t.Errorf("U128(): got %+v, want %+v", got, want)
+	}
+	if got := want.UUID(); got != u {
+		t.Errorf("U128.UUID(): got %v, want %v", got, u)
+	}
+	if got := Max.U128(); got != (U128{Hi: ^uint64(0), Lo: ^uint64(0)}) {
+		t.Errorf("U128(Max): got %+v", got)
+	}
+
+	// Usable as a map key
+	m := map[U128]int{u.U128(): 1}
+	if m[want] != 1 {
+		t.Error("U128 should work as a map key")
+	}
+}
+
+func TestVerifyFacade(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	other := Key{K0: 0xfedcba9876543210, K1: 0x0123456789abcdef}
+	v7 := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	facade := Encode(v7, key)
+
+	corrupted := facade
+	corrupted[3] ^= 0x01
+
+	tests := []struct {
+		name   string
+		facade UUID
+		key    Key
+		want   bool
+	}{
+		{"match", facade, key, true},
+		{"corrupted", corrupted, key, false},
+		{"wrong-key", facade, other, false},
+		{"not-encoded", v7, key, false},
+	}
+	for _, tt := range tests {
+		if got := VerifyFacade(v7, tt.facade, tt.key); got != tt.want {
+			t.Errorf("VerifyFacade(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeToString(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	v7, err := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "2463c780-7fca-4def-8c3f-7b1a2c4d5e6f"
+	if got := v7.EncodeToString(key); got != want {
+		t.Errorf("EncodeToString(): got %s, want %s", got, want)
+	}
+
+	facade := Encode(v7, key)
+	if got := v7.EncodeToString(key); got != facade.String() {
+		t.Errorf("EncodeToString(): got %s, want Encode().String() %s", got, facade.String())
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = v7.EncodeToString(key) }); n > 1 {
+		t.Errorf("EncodeToString() allocations: got %v, want at most 1", n)
+	}
+}
+
+func TestHasDegenerateRandom(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		want bool
+	}{
+		{"zeros", NewV7Exact(1704067200000, 0, 0), true},
+		{"ones", NewV7Exact(1704067200000, 0x0FFF, 0x3FFFFFFFFFFFFFFF), true},
+		{"nil", Nil, true},
+		{"max", Max, true},
+		{"random", NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF), false},
+		{"counter-only", NewV7Exact(1704067200000, 1, 0), false},
+		{"rand-b-only", NewV7Exact(1704067200000, 0, 1), false},
+		{"mixed", NewV7Exact(1704067200000, 0x0FFF, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.u.HasDegenerateRandom(); got != tt.want {
+			t.Errorf("HasDegenerateRandom(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCopyTo(t *testing.T) {
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+
+	frame := bytes.Repeat([]byte{0xEE}, 20)
+	n, err := u.CopyTo(frame[2:])
+	if n != 16 || err != nil {
+		t.Fatalf("CopyTo(): got %d, %v, want 16, nil", n, err)
+	}
+	if !bytes.Equal(frame[2:18], u[:]) || frame[1] != 0xEE || frame[18] != 0xEE {
+		t.Errorf("CopyTo(): got frame %x", frame)
+	}
+
+	// dst must not alias the UUID
+	frame[2] ^= 0xFF
+	if u[0] == frame[2] {
+		t.Error("CopyTo() should copy, not alias")
+	}
+
+	short := make([]byte, 15)
+	if n, err := u.CopyTo(short); n != 0 || err != ErrInvalidByteSlice {
+		t.Errorf("CopyTo(short): got %d, %v, want 0, %v", n, err, ErrInvalidByteSlice)
+	}
+	if !bytes.Equal(short, make([]byte, 15)) {
+		t.Error("CopyTo(short) should not write to dst")
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	u, _ := Parse("018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", true},
+		{"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F", true},
+		{"018f2D9F-9a2a-7DEF-8c3f-7B1A2c4d5e6F", true},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e60", false},
+		{"018f2d9f9a2a7def8c3f7b1a2c4d5e6f", false},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6g", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := u.EqualFold(tt.s); got != tt.want {
+			t.Errorf("EqualFold(%q): got %v, want %v", tt.s, got, tt.want)
+		}
+	}
+
+	// The zero UUID does not match a malformed string
+	var zero UUID
+	if zero.EqualFold("not-a-uuid") {
+		t.Error("EqualFold() should not match malformed input")
+	}
+}
+
+func TestEncodeDiff(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+
+	for i := range uint64(256) {
+		v7 := NewV7Exact(1704067200000+i, uint16(i*37), i*0x9e3779b97f4a7c15)
+		diff := EncodeDiff(v7, key)
+
+		if got, want := rd48be(diff[0:6]), timestampMask(&v7, key); got != want {
+			t.Errorf("EncodeDiff(%d) bytes 0-5: got 0x%X, want mask 0x%X", i, got, want)
+		}
+		if diff[6] != 0x30 {
+			t.Errorf("EncodeDiff(%d) byte 6: got 0x%02X, want 0x30 (version nibble only)", i, diff[6])
+		}
+		if [9]byte(diff[7:]) != [9]byte{} {
+			t.Errorf("EncodeDiff(%d) bytes 7-15: got %x, want zero", i, diff[7:])
+		}
+	}
+
+	// A non-RFC variant is corrected, which shows in the top bits of byte 8
+	v7 := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	v7[8] &= 0x3F
+	if diff := EncodeDiff(v7, key); diff[8] != 0x80 {
+		t.Errorf("EncodeDiff() with cleared variant byte 8: got 0x%02X, want 0x80", diff[8])
+	}
+}
+
+func TestVersionName(t *testing.T) {
+	tests := []struct {
+		ver  int
+		want string
+	}{
+		{0, "unknown"},
+		{1, "Gregorian time-based"},
+		{2, "DCE security"},
+		{3, "name-based (MD5)"},
+		{4, "random"},
+		{5, "name-based (SHA-1)"},
+		{6, "reordered Gregorian time-based"},
+		{7, "Unix time-ordered"},
+		{8, "custom"},
+		{9, "unknown"},
+		{15, "unknown"},
+	}
+	for _, tt := range tests {
+		u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+		u.SetVersion(tt.ver)
+		if got := u.VersionName(); got != tt.want {
+			t.Errorf("VersionName(%d): got %q, want %q", tt.ver, got, tt.want)
+		}
+	}
+
+	if got := Nil.VersionName(); got != "nil" {
+		t.Errorf("VersionName(Nil): got %q, want %q", got, "nil")
+	}
+	if got := Max.VersionName(); got != "max" {
+		t.Errorf("VersionName(Max): got %q, want %q", got, "max")
+	}
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		b8   byte
+		want string
+	}{
+		{0x00, "NCS"},
+		{0x7F, "NCS"},
+		{0x80, "RFC 4122"},
+		{0xBF, "RFC 4122"},
+		{0xC0, "Microsoft"},
+		{0xDF, "Microsoft"},
+		{0xE0, "reserved"},
+		{0xFF, "reserved"},
+	}
+	for _, tt := range tests {
+		u := UUID{8: tt.b8}
+		if got := u.VariantName(); got != tt.want {
+			t.Errorf("VariantName(0x%02X): got %q, want %q", tt.b8, got, tt.want)
+		}
+	}
+}
+
+func TestLeadingZeroBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		u    UUID
+		want int
+	}{
+		{"nil", Nil, 16},
+		{"max", Max, 0},
+		{"last-byte", UUID{15: 0x01}, 15},
+		{"low-nibble", UUID{2: 0x01}, 2},
+		{"high-nibble", UUID{2: 0x10}, 2},
+		{"early-v7", NewV7Exact(1000, 0, 0), 4},
+		{"v7", NewV7Exact(1704067200000, 0, 0), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.u.LeadingZeroBytes(); got != tt.want {
+			t.Errorf("LeadingZeroBytes(%s): got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPGString(t *testing.T) {
+	// PostgreSQL prints uuid values lowercase and hyphenated, whatever the input
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F", "018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f"},
+		{"a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+		{"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000000"},
+	}
+	for _, tt := range tests {
+		u, err := Parse(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := u.PGString(); got != tt.want {
+			t.Errorf("PGString(%s): got %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	maxInt := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	one := UUID{15: 0x01}
+
+	tests := []struct {
+		name string
+		a, b UUID
+		want *big.Int
+	}{
+		{"equal", one, one, big.NewInt(0)},
+		{"one", one, Nil, big.NewInt(1)},
+		{"minus-one", Nil, one, big.NewInt(-1)},
+		{"borrow", UUID{7: 0x01}, UUID{8: 0xFF, 9: 0xFF, 10: 0xFF, 11: 0xFF, 12: 0xFF, 13: 0xFF, 14: 0xFF, 15: 0xFF}, big.NewInt(1)},
+		{"full-range", Max, Nil, maxInt},
+		{"full-range-negative", Nil, Max, new(big.Int).Neg(maxInt)},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got.Cmp(tt.want) != 0 {
+			t.Errorf("Distance(%s): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Consistent with Next
+	u := NewV7Exact(1704067200000, 0x0ABC, 0x0123456789ABCDEF)
+	next, _ := u.Next()
+	if Distance(next, u).Cmp(big.NewInt(1)) != 0 {
+		t.Error("Distance(u.Next(), u) should be 1")
+	}
+}
+
+func TestFacadeMatchesV7(t *testing.T) {
+	key := Key{K0: 0x0123456789abcdef, K1: 0xfedcba9876543210}
+	facade, _ := Parse("2463c780-7fca-4def-8c3f-7b1a2c4d5e6f")
+
+	tests := []struct {
+		v7Str   string
+		want    bool
+		wantErr error
+	}{
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e6f", true, nil},
+		{"018F2D9F-9A2A-7DEF-8C3F-7B1A2C4D5E6F", true, nil},
+		{"018f2d9f-9a2a-7def-8c3f-7b1a2c4d5e60", false, nil},
+		{"018f2d9f-9a2a-7def-8c3f", false, ErrInvalidLength},
+		{"018f2d9f_9a2a-7def-8c3f-7b1a2c4d5e6f", false, ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		got, err := FacadeMatchesV7(facade, tt.v7Str, key)
+		if got != tt.want || err != tt.wantErr {
+			t.Errorf("FacadeMatchesV7(%q): got %v, %v, want %v, %v", tt.v7Str, got, err, tt.want, tt.wantErr)
+		}
+	}
+}